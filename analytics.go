@@ -0,0 +1,132 @@
+package gosite
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// gtagJS is the Google tag loader used for Analytics.GA4MeasurementID.
+const gtagJS = "https://www.googletagmanager.com/gtag/js"
+
+// consentHold is added to the scripts that must wait for analytics consent.
+const consentHold = ` type="text/plain" data-consent="analytics"`
+
+// renderAnalyticsHead returns the GA4 gtag snippet and Analytics.HeadSnippet
+// for the page head, or "" when neither is set. The measurement ID is read
+// from a data attribute so it never needs escaping inside the script.
+// With RequireConsent the scripts of both are held until consent.
+func renderAnalyticsHead(cfg *Config) string {
+	a := cfg.Analytics
+	b := Convert()
+	if id := a.GA4MeasurementID; id != "" {
+		nonce := scriptNonce(cfg)
+		if a.RequireConsent {
+			nonce += consentHold
+		}
+		idEsc := Convert(id).EscapeAttr()
+		b.Write(Fmt("  <script%s async src=\"%s?id=%s\"></script>\n", nonce, gtagJS, idEsc))
+		b.Write(Fmt(`  <script%s data-ga-id="%s">
+window.dataLayer = window.dataLayer || [];
+function gtag() { dataLayer.push(arguments); }
+gtag('js', new Date());
+gtag('config', document.currentScript.dataset.gaId);
+</script>
+`, nonce, idEsc))
+	}
+	if a.HeadSnippet != "" {
+		b.Write("  ")
+		b.Write(analyticsSnippet(cfg, a.HeadSnippet))
+		b.Write("\n")
+	}
+	return b.String()
+}
+
+// analyticsSnippet returns snippet with its <script> tags held as consentHold
+// when Analytics.RequireConsent is set, so a custom tracker does not run before
+// consent either. The type of a held script is replaced, so it runs as a
+// classic script once activated.
+func analyticsSnippet(cfg *Config, snippet string) string {
+	if !cfg.Analytics.RequireConsent {
+		return snippet
+	}
+	const tag = "<script"
+	out := Convert()
+	last := 0
+	for i := 0; i+len(tag) < len(snippet); i++ {
+		if !hasPrefixFold(snippet[i:], tag) {
+			continue
+		}
+		switch snippet[i+len(tag)] {
+		case ' ', '\t', '\n', '\r', '>':
+			out.Write(snippet[last : i+len(tag)])
+			out.Write(consentHold)
+			last = i + len(tag)
+		}
+	}
+	out.Write(snippet[last:])
+	return out.String()
+}
+
+// hasPrefixFold reports whether s starts with the lowercase ASCII prefix,
+// ignoring the case of s.
+func hasPrefixFold(s, prefix string) bool {
+	if len(s) < len(prefix) {
+		return false
+	}
+	for i := 0; i < len(prefix); i++ {
+		c := s[i]
+		if c >= 'A' && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		if c != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// renderConsentActivator returns the inline script that runs the scripts held
+// as type="text/plain" data-consent, by replacing each with an executable
+// copy. It activates them at once when localStorage "cookie-consent" is
+// "accepted", and otherwise when a consent banner dispatches the
+// "consent:accept" event on document.
+func renderConsentActivator(nonceAttr string) string {
+	//*js
+	return Fmt(`  <script%s>
+(function() {
+	function activate() {
+		document.querySelectorAll('script[type="text/plain"][data-consent]').forEach(function(held) {
+			var script = document.createElement('script');
+			Array.prototype.forEach.call(held.attributes, function(attr) {
+				if (attr.name !== 'type' && attr.name !== 'data-consent') script.setAttribute(attr.name, attr.value);
+			});
+			script.nonce = held.nonce;
+			script.text = held.text;
+			held.replaceWith(script);
+		});
+	}
+	try {
+		if (localStorage.getItem('cookie-consent') === 'accepted') return activate();
+	} catch (e) {}
+	document.addEventListener('consent:accept', activate, { once: true });
+})();
+</script>
+`, nonceAttr)
+}
+
+// renderAnalyticsBody returns Analytics.BodySnippet for the end of the body,
+// followed with RequireConsent by the activator of the held scripts, or "".
+// The activator comes last so every held script is parsed when it runs.
+func renderAnalyticsBody(cfg *Config) string {
+	a := cfg.Analytics
+	b := Convert()
+	if a.BodySnippet != "" {
+		b.Write("  ")
+		b.Write(analyticsSnippet(cfg, a.BodySnippet))
+		b.Write("\n")
+	}
+	if a.RequireConsent && (a.GA4MeasurementID != "" ||
+		Contains(analyticsSnippet(cfg, a.HeadSnippet+a.BodySnippet), consentHold)) {
+		b.Write(renderConsentActivator(scriptNonce(cfg)))
+	}
+	return b.String()
+}
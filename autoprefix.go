@@ -0,0 +1,116 @@
+//go:build !wasm
+
+package gosite
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// prefixedProperties lists the properties Config.Autoprefix completes with
+// vendor-prefixed copies. The set is kept small on purpose: only properties
+// whose prefixed form takes exactly the same values.
+var prefixedProperties = map[string][]string{
+	"appearance":           {"-webkit-", "-moz-"},
+	"backdrop-filter":      {"-webkit-"},
+	"box-decoration-break": {"-webkit-"},
+	"hyphens":              {"-webkit-"},
+	"mask-image":           {"-webkit-"},
+	"tab-size":             {"-moz-"},
+	"text-size-adjust":     {"-webkit-", "-moz-"},
+	"user-select":          {"-webkit-", "-moz-"},
+}
+
+// autoprefixCSS inserts vendor-prefixed copies before the declarations of
+// prefixedProperties, and "position: -webkit-sticky" before "position:
+// sticky". Only declarations inside a block are touched: selectors, at-rule
+// preludes, comments, strings and parentheses are copied unchanged.
+func autoprefixCSS(css string) string {
+	out := make([]byte, 0, len(css)+len(css)/16)
+	depth := 0
+	segStart := 0 // start of the current declaration or selector in css
+	var quote byte
+	parens := 0
+
+	for i := 0; i < len(css); i++ {
+		c := css[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		case c == '/' && i+1 < len(css) && css[i+1] == '*':
+			end := Index(css[i+2:], "*/")
+			if end < 0 {
+				i = len(css) - 1
+			} else {
+				i += end + 3
+			}
+			continue
+		case c == '"' || c == '\'':
+			quote = c
+			continue
+		case c == '(':
+			parens++
+			continue
+		case c == ')':
+			if parens > 0 {
+				parens--
+			}
+			continue
+		case parens > 0:
+			continue
+		}
+
+		switch c {
+		case '{':
+			out = append(out, css[segStart:i+1]...)
+			depth++
+			segStart = i + 1
+		case ';', '}':
+			seg := css[segStart:i]
+			if depth > 0 {
+				seg = prefixDeclaration(seg)
+			}
+			out = append(out, seg...)
+			out = append(out, c)
+			if c == '}' && depth > 0 {
+				depth--
+			}
+			segStart = i + 1
+		}
+	}
+	out = append(out, css[segStart:]...)
+	return string(out)
+}
+
+// prefixDeclaration returns decl preceded by its prefixed copies, or decl
+// unchanged when it needs none or does not look like "property: value".
+func prefixDeclaration(decl string) string {
+	body := Convert(decl).TrimSpace().String()
+	colon := Index(body, ":")
+	if body == "" || colon <= 0 || HasPrefix(body, "-") || Contains(body, "/*") {
+		return decl
+	}
+	prop := Convert(body[:colon]).TrimSpace().ToLower().String()
+	value := Convert(body[colon+1:]).TrimSpace().String()
+	if value == "" {
+		return decl
+	}
+
+	lead := decl[:Index(decl, body)]
+	extra := ""
+	for _, prefix := range prefixedProperties[prop] {
+		extra += prefix + body + ";" + lead
+	}
+	if v := Convert(value).ToLower().String(); prop == "position" && (v == "sticky" || HasPrefix(v, "sticky ") || HasPrefix(v, "sticky!")) {
+		rest := body[colon+1:]
+		extra += body[:colon+1] + rest[:Index(rest, value)] + "-webkit-" + value + ";" + lead
+	}
+	if extra == "" {
+		return decl
+	}
+	return lead + extra + decl[len(lead):]
+}
@@ -9,6 +9,7 @@ type Card struct {
 	Title       string
 	Description string
 	Icon        string
+	Reveal      bool // Fade in on scroll with gosite.Config.ScrollReveal
 	CSSClass    string
 }
 
@@ -30,13 +31,18 @@ func (c *Card) RenderHTML() string {
 		iconHTML = Fmt("  <svg class=\"icon\"><use href=\"icons.svg#%s\"></use></svg>\n", iconEsc)
 	}
 
-	tpl := `<div class="%s">
+	reveal := ""
+	if c.Reveal {
+		reveal = " data-reveal"
+	}
+
+	tpl := `<div class="%s"%s>
 %s  <h3>%s</h3>
   <p>%s</p>
 </div>
 `
 
-	return Fmt(tpl, classEsc, iconHTML, titleEsc, descEsc)
+	return Fmt(tpl, classEsc, reveal, iconHTML, titleEsc, descEsc)
 }
 
 // RenderCSS returns the CSS for the card.
@@ -12,6 +12,7 @@ type CarouselImage struct {
 
 // Carousel implements HTMLRenderer, CSSRenderer, and JSRenderer interfaces.
 type Carousel struct {
+	ID     string // Element id; required to bind the frontend (WASM) behavior
 	Images []CarouselImage
 }
 
@@ -25,11 +26,16 @@ func (c *Carousel) RenderHTML() string {
 		items += Fmt("  <div class=\"carousel-item\"><img src=\"%s\" alt=\"%s\"></div>\n", src, alt)
 	}
 
-	tpl := `<div class="carousel">
+	id := ""
+	if c.ID != "" {
+		id = Fmt(" id=\"%s\"", Convert(c.ID).EscapeAttr())
+	}
+
+	tpl := `<div class="carousel"%s>
 %s</div>
 `
 
-	return Fmt(tpl, items)
+	return Fmt(tpl, id, items)
 }
 
 // RenderCSS returns the CSS for the carousel.
@@ -55,6 +61,9 @@ func (c *Carousel) RenderCSS() string {
 `
 }
 
+// JSKey names the carousel ES module (see gosite.JSKeyRenderer).
+func (c *Carousel) JSKey() string { return "carousel" }
+
 // RenderJS returns the JavaScript for the carousel.
 func (c *Carousel) RenderJS() string {
 	return `// Carousel auto-slide
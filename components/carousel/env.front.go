@@ -0,0 +1,78 @@
+//go:build wasm
+// +build wasm
+
+package carousel
+
+import (
+	"syscall/js"
+	"time"
+
+	"github.com/cdvelop/gosite"
+)
+
+// autoplays holds the running slide tickers by carousel ID, so mounting a
+// carousel again stops the previous one.
+var autoplays = map[string]autoplay{}
+
+type autoplay struct {
+	ticker *time.Ticker
+	done   chan struct{}
+}
+
+func (a autoplay) stop() {
+	a.ticker.Stop()
+	close(a.done)
+}
+
+// BindEvents shows the first slide, advances on click and every 3 seconds,
+// replacing RenderJS in the frontend (WASM). It needs ID to find the element.
+// The timer stops once the element leaves the document or the carousel is
+// mounted again.
+func (c *Carousel) BindEvents(b gosite.EventBinder) {
+	if c.ID == "" {
+		return
+	}
+	if previous, ok := autoplays[c.ID]; ok {
+		previous.stop()
+		delete(autoplays, c.ID)
+	}
+	el := js.Global().Get("document").Call("getElementById", c.ID)
+	if el.IsNull() {
+		return
+	}
+	items := el.Call("querySelectorAll", ".carousel-item")
+	count := items.Length()
+	if count == 0 {
+		return
+	}
+
+	current := 0
+	items.Index(current).Get("classList").Call("add", "active")
+	next := func() {
+		items.Index(current).Get("classList").Call("remove", "active")
+		current = (current + 1) % count
+		items.Index(current).Get("classList").Call("add", "active")
+	}
+
+	b.EventListener(true, c.ID, "click", next)
+	if count < 2 {
+		return // nothing to rotate
+	}
+
+	a := autoplay{ticker: time.NewTicker(3 * time.Second), done: make(chan struct{})}
+	autoplays[c.ID] = a
+	go func() {
+		for {
+			select {
+			case <-a.done:
+				return
+			case <-a.ticker.C:
+				if !el.Get("isConnected").Bool() {
+					a.ticker.Stop()
+					return
+				}
+				next()
+			}
+		}
+	}()
+}
@@ -0,0 +1,63 @@
+package avatar
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// Avatar implements HTMLRenderer and CSSRenderer interfaces.
+// It renders a circular profile image, or the initials of Name when ImageSrc
+// is empty.
+type Avatar struct {
+	ImageSrc string
+	Alt      string // Image alt text (default: Name)
+	Name     string
+	Size     int // Diameter in px (default 48)
+	CSSClass string
+}
+
+// RenderHTML generates the HTML for the avatar.
+func (a *Avatar) RenderHTML() string {
+	class := "avatar"
+	if a.CSSClass != "" {
+		class += " " + a.CSSClass
+	}
+
+	size := a.Size
+	if size <= 0 {
+		size = 48
+	}
+
+	classEsc := Convert(class).EscapeAttr()
+
+	if a.ImageSrc != "" {
+		alt := a.Alt
+		if alt == "" {
+			alt = a.Name
+		}
+		srcEsc := Convert(a.ImageSrc).EscapeAttr()
+		altEsc := Convert(alt).EscapeAttr()
+		return Fmt(`<img class="%s" src="%s" alt="%s" width="%d" height="%d" style="--avatar-size: %dpx;">`,
+			classEsc, srcEsc, altEsc, size, size, size)
+	}
+
+	nameEsc := Convert(a.Name).EscapeAttr()
+	initialsEsc := Convert(initials(a.Name)).EscapeHTML()
+
+	return Fmt(`<span class="%s avatar-initials" role="img" aria-label="%s" style="--avatar-size: %dpx;">%s</span>`,
+		classEsc, nameEsc, size, initialsEsc)
+}
+
+// initials returns the upper-cased first letters of the first two words of name.
+func initials(name string) string {
+	out := ""
+	for _, word := range Convert(name).Split(" ") {
+		if word == "" {
+			continue
+		}
+		out += string([]rune(word)[0])
+		if len([]rune(out)) == 2 {
+			break
+		}
+	}
+	return Convert(out).ToUpper().String()
+}
@@ -0,0 +1,16 @@
+//go:build !wasm
+// +build !wasm
+
+package avatar
+
+import (
+	_ "embed"
+)
+
+//go:embed style.css
+var styleCss string
+
+// RenderCSS returns the CSS for the avatar.
+func (a *Avatar) RenderCSS() string {
+	return styleCss
+}
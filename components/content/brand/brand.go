@@ -0,0 +1,43 @@
+package brand
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// Brand implements HTMLRenderer and CSSRenderer interfaces.
+// It renders the site logo with an optional wordmark, linked to Href.
+type Brand struct {
+	ImageSrc string // logo image; omitted when empty
+	Alt      string // logo alt text; the logo is decorative when empty and Text is set
+	Text     string // optional wordmark shown next to the logo
+	Href     string // link target; rendered as a plain <span> when empty
+	CSSClass string
+}
+
+// RenderHTML generates the HTML for the brand.
+func (b *Brand) RenderHTML() string {
+	class := "brand"
+	if b.CSSClass != "" {
+		class += " " + b.CSSClass
+	}
+	classEsc := Convert(class).EscapeAttr()
+
+	content := ""
+	if b.ImageSrc != "" {
+		role := ""
+		if b.Alt == "" && b.Text != "" {
+			// The wordmark names the link, so the logo adds nothing for screen readers
+			role = ` role="presentation"`
+		}
+		content += Fmt(`<img class="brand-logo" src="%s" alt="%s"%s>`,
+			Convert(b.ImageSrc).EscapeAttr(), Convert(b.Alt).EscapeAttr(), role)
+	}
+	if b.Text != "" {
+		content += Fmt(`<span class="brand-text">%s</span>`, Convert(b.Text).EscapeHTML())
+	}
+
+	if b.Href == "" {
+		return Fmt(`<span class="%s">%s</span>`, classEsc, content)
+	}
+	return Fmt(`<a href="%s" class="%s">%s</a>`, Convert(b.Href).EscapeAttr(), classEsc, content)
+}
@@ -0,0 +1,16 @@
+//go:build !wasm
+// +build !wasm
+
+package brand
+
+import (
+	_ "embed"
+)
+
+//go:embed style.css
+var styleCss string
+
+// RenderCSS returns the CSS for the brand.
+func (b *Brand) RenderCSS() string {
+	return styleCss
+}
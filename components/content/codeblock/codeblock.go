@@ -0,0 +1,62 @@
+package codeblock
+
+import (
+	"github.com/cdvelop/gosite/components/i18n"
+	. "github.com/cdvelop/tinystring"
+)
+
+// Highlighter colors source code on the Go side, so no highlighting script
+// is needed in the browser. Highlight returns the code as HTML (usually
+// <span> elements with the classes of the highlighter's stylesheet) and
+// must escape the text itself; ok false falls back to plain escaped text.
+type Highlighter interface {
+	Highlight(language, code string) (html string, ok bool)
+}
+
+// CodeBlock implements HTMLRenderer, CSSRenderer and JSRenderer interfaces.
+// It renders preformatted source code with an optional copy-to-clipboard
+// button. Content is kept byte for byte, whitespace included.
+type CodeBlock struct {
+	Language string // Adds class="language-x" to <code>, e.g. "go"
+	Content  string
+	ShowCopy bool
+	// Optional: pre-renders the highlighting. The block is then marked
+	// "nohighlight" so gosite.Config.SyntaxHighlight leaves it alone.
+	Highlighter Highlighter
+	CSSClass    string
+}
+
+// RenderHTML generates the HTML for the code block.
+func (c *CodeBlock) RenderHTML() string {
+	class := "code-block"
+	if c.CSSClass != "" {
+		class += " " + c.CSSClass
+	}
+	classEsc := Convert(class).EscapeAttr()
+
+	content := Convert(c.Content).EscapeHTML()
+	codeClass := ""
+	if c.Language != "" {
+		codeClass = "language-" + c.Language
+	}
+	if c.Highlighter != nil {
+		if html, ok := c.Highlighter.Highlight(c.Language, c.Content); ok {
+			content = html
+			codeClass = Convert(codeClass + " nohighlight").TrimSpace().String()
+		}
+	}
+	langClass := ""
+	if codeClass != "" {
+		langClass = Fmt(" class=\"%s\"", Convert(codeClass).EscapeAttr())
+	}
+
+	copyHTML := ""
+	if c.ShowCopy {
+		copyHTML = Fmt("<button type=\"button\" class=\"code-copy\" data-copied=\"%s\">%s</button>",
+			Translate(i18n.D.Copied).EscapeAttr(), Translate(i18n.D.Copy).EscapeHTML())
+	}
+
+	// No whitespace inside <pre>: it would become part of the code
+	return Fmt(`<div class="%s">%s<pre><code%s>%s</code></pre></div>
+`, classEsc, copyHTML, langClass, content)
+}
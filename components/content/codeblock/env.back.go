@@ -0,0 +1,24 @@
+//go:build !wasm
+// +build !wasm
+
+package codeblock
+
+import (
+	_ "embed"
+)
+
+//go:embed style.css
+var styleCss string
+
+// RenderCSS returns the CSS for the code block.
+func (c *CodeBlock) RenderCSS() string {
+	return styleCss
+}
+
+//go:embed script.js
+var scriptJs string
+
+// RenderJS returns the JavaScript for the code block.
+func (c *CodeBlock) RenderJS() string {
+	return scriptJs
+}
@@ -0,0 +1,20 @@
+// Component: CodeBlock
+(function() {
+  const buttons = document.querySelectorAll('.code-block .code-copy');
+
+  buttons.forEach(function(button) {
+    const label = button.textContent;
+
+    button.addEventListener('click', function() {
+      const code = button.parentElement.querySelector('code');
+      if (!code || !navigator.clipboard) return;
+
+      navigator.clipboard.writeText(code.textContent).then(function() {
+        button.textContent = button.dataset.copied;
+        setTimeout(function() {
+          button.textContent = label;
+        }, 2000);
+      });
+    });
+  });
+})();
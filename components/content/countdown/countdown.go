@@ -0,0 +1,73 @@
+package countdown
+
+import (
+	"time"
+
+	"github.com/cdvelop/gosite/components/i18n"
+	. "github.com/cdvelop/tinystring"
+)
+
+// Countdown implements HTMLRenderer, CSSRenderer, and JSRenderer interfaces.
+// It counts down to a moment in days, hours, minutes and seconds, and shows
+// ExpiredText once it is reached, e.g. for a product launch or an event.
+type Countdown struct {
+	TargetISO   string // ISO-8601 timestamp, e.g. "2026-12-31T23:59:00-03:00"; UTC when it has no offset
+	ExpiredText string
+	CSSClass    string
+}
+
+// targetLayouts are the accepted TargetISO formats. Layouts without an
+// offset are parsed as UTC, so the target does not depend on the time zone
+// of the build machine or the visitor.
+var targetLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"}
+
+// target returns the target moment normalized to UTC.
+func (c *Countdown) target() (time.Time, bool) {
+	for _, layout := range targetLayouts {
+		if t, err := time.Parse(layout, c.TargetISO); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// RenderHTML generates the HTML for the countdown. An unparsable TargetISO
+// renders the expired state.
+func (c *Countdown) RenderHTML() string {
+	class := "countdown"
+	if c.CSSClass != "" {
+		class += " " + c.CSSClass
+	}
+	classEsc := Convert(class).EscapeAttr()
+	expiredEsc := Convert(c.ExpiredText).EscapeHTML()
+
+	target, ok := c.target()
+	if !ok {
+		return Fmt("<div class=\"%s countdown-expired\">%s</div>\n", classEsc, expiredEsc)
+	}
+	stamp := target.Format(time.RFC3339)
+
+	unitsHTML := ""
+	units := []struct {
+		name  string
+		label LocStr
+	}{
+		{"days", i18n.D.Days},
+		{"hours", i18n.D.Hours},
+		{"minutes", i18n.D.Minutes},
+		{"seconds", i18n.D.Seconds},
+	}
+	for _, u := range units {
+		unitsHTML += Fmt(`        <span class="countdown-unit">
+            <span class="countdown-value" data-unit="%s">--</span>
+            <span class="countdown-label text-sm">%s</span>
+        </span>
+`, u.name, Translate(u.label).EscapeHTML())
+	}
+
+	return Fmt(`<div class="%s" role="timer" data-target="%s" data-expired="%s">
+    <time class="countdown-boxes" datetime="%s">
+%s    </time>
+</div>
+`, classEsc, stamp, Convert(c.ExpiredText).EscapeAttr(), stamp, unitsHTML)
+}
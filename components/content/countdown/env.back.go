@@ -0,0 +1,24 @@
+//go:build !wasm
+// +build !wasm
+
+package countdown
+
+import (
+	_ "embed"
+)
+
+//go:embed style.css
+var styleCss string
+
+// RenderCSS returns the CSS for the countdown.
+func (c *Countdown) RenderCSS() string {
+	return styleCss
+}
+
+//go:embed script.js
+var scriptJs string
+
+// RenderJS returns the JavaScript for the countdown.
+func (c *Countdown) RenderJS() string {
+	return scriptJs
+}
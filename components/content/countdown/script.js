@@ -0,0 +1,40 @@
+// Component: Countdown
+(function() {
+  const timers = document.querySelectorAll('.countdown[data-target]');
+
+  timers.forEach(function(timer) {
+    // data-target is RFC 3339 with an explicit offset, so Date parses it
+    // the same in every time zone.
+    const target = Date.parse(timer.dataset.target);
+    if (isNaN(target)) return;
+
+    const values = {};
+    timer.querySelectorAll('[data-unit]').forEach(function(el) {
+      values[el.dataset.unit] = el;
+    });
+
+    let interval;
+    function tick() {
+      const left = Math.max(0, Math.floor((target - Date.now()) / 1000));
+      if (left === 0) {
+        clearInterval(interval);
+        timer.classList.add('countdown-expired');
+        timer.textContent = timer.dataset.expired;
+        return false;
+      }
+      const parts = {
+        days: Math.floor(left / 86400),
+        hours: Math.floor(left % 86400 / 3600),
+        minutes: Math.floor(left % 3600 / 60),
+        seconds: left % 60
+      };
+      Object.keys(parts).forEach(function(unit) {
+        if (values[unit]) values[unit].textContent = String(parts[unit]).padStart(2, '0');
+      });
+      return true;
+    }
+
+    // A target already in the past expires on the first tick; no interval.
+    if (tick()) interval = setInterval(tick, 1000);
+  });
+})();
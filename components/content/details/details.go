@@ -0,0 +1,35 @@
+package details
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// Details implements HTMLRenderer and CSSRenderer interfaces.
+// It renders a single collapsible block with the native <details> element,
+// so it works without JavaScript.
+type Details struct {
+	Summary  string
+	Content  string
+	Open     bool // Start expanded
+	CSSClass string
+}
+
+// RenderHTML generates the HTML for the details block.
+func (d *Details) RenderHTML() string {
+	class := "details"
+	if d.CSSClass != "" {
+		class += " " + d.CSSClass
+	}
+	classEsc := Convert(class).EscapeAttr()
+
+	open := ""
+	if d.Open {
+		open = " open"
+	}
+
+	return Fmt(`<details class="%s"%s>
+    <summary>%s</summary>
+    <div class="details-content text text-md">%s</div>
+</details>
+`, classEsc, open, Convert(d.Summary).EscapeHTML(), Convert(d.Content).EscapeHTML())
+}
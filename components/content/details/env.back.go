@@ -0,0 +1,16 @@
+//go:build !wasm
+// +build !wasm
+
+package details
+
+import (
+	_ "embed"
+)
+
+//go:embed style.css
+var styleCss string
+
+// RenderCSS returns the CSS for the details block.
+func (d *Details) RenderCSS() string {
+	return styleCss
+}
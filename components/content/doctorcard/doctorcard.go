@@ -12,6 +12,7 @@ type DoctorCard struct {
 	ImageSrc  string
 	ImageAlt  string
 	BgColor   string
+	Reveal    bool // Fade in on scroll with gosite.Config.ScrollReveal
 	CSSClass  string
 }
 
@@ -34,7 +35,12 @@ func (d *DoctorCard) RenderHTML() string {
 	nameEsc := Convert(d.Name).EscapeHTML()
 	specialtyEsc := Convert(d.Specialty).EscapeHTML()
 
-	tpl := `    <div class="%s">
+	reveal := ""
+	if d.Reveal {
+		reveal = " data-reveal"
+	}
+
+	tpl := `    <div class="%s"%s role="group" aria-label="%s">
         <div class="img flex">
             <img src="%s" alt="%s">
             <div class="%s">
@@ -45,5 +51,5 @@ func (d *DoctorCard) RenderHTML() string {
     </div>
 `
 
-	return Fmt(tpl, classEsc, imageSrcEsc, imageAltEsc, bgClassEsc, nameEsc, specialtyEsc)
+	return Fmt(tpl, classEsc, reveal, Convert(d.Name).EscapeAttr(), imageSrcEsc, imageAltEsc, bgClassEsc, nameEsc, specialtyEsc)
 }
@@ -0,0 +1,16 @@
+//go:build !wasm
+// +build !wasm
+
+package faq
+
+import (
+	_ "embed"
+)
+
+//go:embed style.css
+var styleCss string
+
+// RenderCSS returns the CSS for the FAQ.
+func (f *FAQ) RenderCSS() string {
+	return styleCss
+}
@@ -0,0 +1,78 @@
+package faq
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// QA is a single question and its answer.
+type QA struct {
+	Question string
+	Answer   string
+}
+
+// FAQ implements HTMLRenderer and CSSRenderer interfaces.
+// It renders the questions as a disclosure list and embeds the matching
+// schema.org FAQPage JSON-LD so search engines can show them as rich results.
+type FAQ struct {
+	Items    []QA
+	CSSClass string
+}
+
+// RenderHTML generates the HTML for the FAQ, followed by its JSON-LD script.
+func (f *FAQ) RenderHTML() string {
+	class := "faq"
+	if f.CSSClass != "" {
+		class += " " + f.CSSClass
+	}
+	classEsc := Convert(class).EscapeAttr()
+
+	itemsHTML := ""
+	entities := ""
+	for i, item := range f.Items {
+		itemsHTML += Fmt(`        <details class="faq-item">
+            <summary class="faq-question">%s</summary>
+            <div class="faq-answer text text-md">%s</div>
+        </details>
+`, Convert(item.Question).EscapeHTML(), Convert(item.Answer).EscapeHTML())
+
+		if i > 0 {
+			entities += ","
+		}
+		entities += Fmt(`{"@type":"Question","name":%s,"acceptedAnswer":{"@type":"Answer","text":%s}}`,
+			jsonString(item.Question), jsonString(item.Answer))
+	}
+
+	tpl := `    <div class="%s">
+%s    </div>
+    <script type="application/ld+json">{"@context":"https://schema.org","@type":"FAQPage","mainEntity":[%s]}</script>
+`
+
+	return Fmt(tpl, classEsc, itemsHTML, entities)
+}
+
+// jsonString returns s as a quoted JSON string. "<", ">" and "&" are written
+// as \u escapes so the text can never close the surrounding <script>.
+func jsonString(s string) string {
+	const hex = "0123456789abcdef"
+	out := make([]byte, 0, len(s)+2)
+	out = append(out, '"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\\':
+			out = append(out, '\\', c)
+		case c == '\n':
+			out = append(out, '\\', 'n')
+		case c == '\r':
+			out = append(out, '\\', 'r')
+		case c == '\t':
+			out = append(out, '\\', 't')
+		case c < 0x20 || c == '<' || c == '>' || c == '&':
+			out = append(out, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+		default:
+			out = append(out, c)
+		}
+	}
+	out = append(out, '"')
+	return string(out)
+}
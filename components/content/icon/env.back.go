@@ -0,0 +1,16 @@
+//go:build !wasm
+// +build !wasm
+
+package icon
+
+import (
+	_ "embed"
+)
+
+//go:embed style.css
+var styleCss string
+
+// RenderCSS returns the CSS for the icon.
+func (i *Icon) RenderCSS() string {
+	return styleCss
+}
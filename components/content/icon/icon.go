@@ -0,0 +1,39 @@
+package icon
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// Icon implements HTMLRenderer and CSSRenderer interfaces.
+// It references a symbol of the icons.svg sprite registered with Site.AddIcon.
+type Icon struct {
+	Name     string // symbol id in the sprite
+	Title    string // accessible name; decorative (aria-hidden) when empty
+	Size     int    // width and height in px; 0 keeps the CSS default (1em)
+	CSSClass string
+}
+
+// RenderHTML generates the HTML for the icon.
+func (i *Icon) RenderHTML() string {
+	class := "icon"
+	if i.CSSClass != "" {
+		class += " " + i.CSSClass
+	}
+
+	classEsc := Convert(class).EscapeAttr()
+	nameEsc := Convert(i.Name).EscapeAttr()
+
+	size := ""
+	if i.Size > 0 {
+		size = Fmt(` style="width:%dpx;height:%dpx"`, i.Size, i.Size)
+	}
+
+	a11y := ` aria-hidden="true" focusable="false"`
+	title := ""
+	if i.Title != "" {
+		a11y = ` role="img"`
+		title = Fmt("<title>%s</title>", Convert(i.Title).EscapeHTML())
+	}
+
+	return Fmt(`<svg class="%s"%s%s>%s<use href="icons.svg#%s"></use></svg>`, classEsc, size, a11y, title, nameEsc)
+}
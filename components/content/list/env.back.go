@@ -0,0 +1,16 @@
+//go:build !wasm
+// +build !wasm
+
+package list
+
+import (
+	_ "embed"
+)
+
+//go:embed style.css
+var styleCss string
+
+// RenderCSS returns the CSS for the list.
+func (l *List) RenderCSS() string {
+	return styleCss
+}
@@ -0,0 +1,68 @@
+package list
+
+import (
+	"github.com/cdvelop/gosite/components/content/icon"
+	. "github.com/cdvelop/tinystring"
+)
+
+// ListItem is one entry of a list.
+type ListItem struct {
+	Text string
+	Href string // Optional link target
+	Icon string // Optional leading icons.svg symbol
+}
+
+// List implements HTMLRenderer and CSSRenderer interfaces.
+// It renders a styled <ul>, or an <ol> when Ordered is set.
+type List struct {
+	Items    []ListItem
+	Ordered  bool
+	CSSClass string
+}
+
+// RenderHTML generates the HTML for the list.
+func (l *List) RenderHTML() string {
+	tag := "ul"
+	class := "list"
+	if l.Ordered {
+		tag = "ol"
+		class += " list-ordered"
+	}
+	if l.CSSClass != "" {
+		class += " " + l.CSSClass
+	}
+	classEsc := Convert(class).EscapeAttr()
+
+	itemsHTML := ""
+	for _, item := range l.Items {
+		content := Convert(item.Text).EscapeHTML()
+		if item.Icon != "" {
+			content = listIcon(item).RenderHTML() + content
+		}
+		if item.Href != "" {
+			content = Fmt(`<a href="%s">%s</a>`, Convert(item.Href).EscapeAttr(), content)
+		}
+		itemsHTML += Fmt("    <li>%s</li>\n", content)
+	}
+
+	return Fmt(`<%s class="%s">
+%s</%s>
+`, tag, classEsc, itemsHTML, tag)
+}
+
+// listIcon returns the decorative leading icon of item.
+func listIcon(item ListItem) *icon.Icon {
+	return &icon.Icon{Name: item.Icon, CSSClass: "list-icon"}
+}
+
+// ChildComponents returns the item icons so their CSS is collected with the
+// list.
+func (l *List) ChildComponents() []any {
+	var children []any
+	for _, item := range l.Items {
+		if item.Icon != "" {
+			children = append(children, listIcon(item))
+		}
+	}
+	return children
+}
@@ -0,0 +1,16 @@
+//go:build !wasm
+// +build !wasm
+
+package mapembed
+
+import (
+	_ "embed"
+)
+
+//go:embed style.css
+var styleCss string
+
+// RenderCSS returns the CSS for the map embed.
+func (m *MapEmbed) RenderCSS() string {
+	return styleCss
+}
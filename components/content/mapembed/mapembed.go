@@ -0,0 +1,41 @@
+package mapembed
+
+import (
+	"github.com/cdvelop/gosite/components/i18n"
+	. "github.com/cdvelop/tinystring"
+)
+
+// MapEmbed implements HTMLRenderer and CSSRenderer interfaces.
+// It renders a responsive, lazy-loaded map iframe (e.g. a Google Maps embed URL).
+type MapEmbed struct {
+	URL      string
+	Width    int    // intrinsic width for the aspect ratio (default 600)
+	Height   int    // intrinsic height for the aspect ratio (default 450)
+	Title    string // accessible iframe title (default translated "Map")
+	CSSClass string
+}
+
+// RenderHTML generates the HTML for the map. The iframe fills the container
+// width and keeps the Width:Height aspect ratio.
+func (m *MapEmbed) RenderHTML() string {
+	class := "map-embed"
+	if m.CSSClass != "" {
+		class += " " + m.CSSClass
+	}
+
+	width, height := m.Width, m.Height
+	if width <= 0 {
+		width = 600
+	}
+	if height <= 0 {
+		height = 450
+	}
+
+	title := Convert(m.Title).EscapeAttr()
+	if m.Title == "" {
+		title = Translate(i18n.D.Map).EscapeAttr()
+	}
+
+	return Fmt(`<div class="%s" style="--map-ratio: %d / %d"><iframe src="%s" width="%d" height="%d" title="%s" loading="lazy" referrerpolicy="no-referrer-when-downgrade" allowfullscreen></iframe></div>`,
+		Convert(class).EscapeAttr(), width, height, Convert(m.URL).EscapeAttr(), width, height, title)
+}
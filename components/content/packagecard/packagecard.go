@@ -12,6 +12,7 @@ type PackageCard struct {
 	IconClass   string
 	ButtonLabel string
 	ButtonHref  string
+	Reveal      bool // Fade in on scroll with gosite.Config.ScrollReveal
 	CSSClass    string
 }
 
@@ -29,7 +30,12 @@ func (p *PackageCard) RenderHTML() string {
 	buttonLabelEsc := Convert(p.ButtonLabel).EscapeHTML()
 	buttonHrefEsc := Convert(p.ButtonHref).EscapeAttr()
 
-	tpl := `    <div class="%s">
+	reveal := ""
+	if p.Reveal {
+		reveal = " data-reveal"
+	}
+
+	tpl := `    <div class="%s"%s>
         <div class="icon flex">
             <i class="%s"></i>
         </div>
@@ -39,5 +45,5 @@ func (p *PackageCard) RenderHTML() string {
     </div>
 `
 
-	return Fmt(tpl, classEsc, iconClassEsc, titleEsc, descriptionEsc, buttonHrefEsc, buttonLabelEsc)
+	return Fmt(tpl, classEsc, reveal, iconClassEsc, titleEsc, descriptionEsc, buttonHrefEsc, buttonLabelEsc)
 }
@@ -14,6 +14,7 @@ type PostCard struct {
 	ContentExtra  string
 	Date          string
 	CommentsCount string
+	Reveal        bool // Fade in on scroll with gosite.Config.ScrollReveal
 	CSSClass      string
 }
 
@@ -33,7 +34,12 @@ func (p *PostCard) RenderHTML() string {
 	dateEsc := Convert(p.Date).EscapeHTML()
 	commentsCountEsc := Convert(p.CommentsCount).EscapeHTML()
 
-	tpl := `    <article class="%s">
+	reveal := ""
+	if p.Reveal {
+		reveal = " data-reveal"
+	}
+
+	tpl := `    <article class="%s"%s>
         <div class="img">
             <img src="%s" alt="%s">
         </div>
@@ -49,5 +55,5 @@ func (p *PostCard) RenderHTML() string {
     </article>
 `
 
-	return Fmt(tpl, classEsc, imageSrcEsc, imageAltEsc, titleEsc, contentEsc, contentExtraEsc, dateEsc, commentsCountEsc)
+	return Fmt(tpl, classEsc, reveal, imageSrcEsc, imageAltEsc, titleEsc, contentEsc, contentExtraEsc, dateEsc, commentsCountEsc)
 }
@@ -0,0 +1,16 @@
+//go:build !wasm
+// +build !wasm
+
+package quote
+
+import (
+	_ "embed"
+)
+
+//go:embed style.css
+var styleCss string
+
+// RenderCSS returns the CSS for the quote.
+func (q *Quote) RenderCSS() string {
+	return styleCss
+}
@@ -0,0 +1,57 @@
+package quote
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// Quote implements HTMLRenderer and CSSRenderer interfaces.
+// It renders an inline quotation as <figure><blockquote> with an optional
+// <figcaption> naming the author and source.
+type Quote struct {
+	Text       string
+	Author     string
+	Source     string // Title of the quoted work, shown in <cite>
+	SourceHref string // Optional URL of the source; links the <cite> when set
+	CSSClass   string
+}
+
+// RenderHTML generates the HTML for the quote.
+func (q *Quote) RenderHTML() string {
+	class := "quote"
+	if q.CSSClass != "" {
+		class += " " + q.CSSClass
+	}
+	classEsc := Convert(class).EscapeAttr()
+
+	citeAttr := ""
+	if q.SourceHref != "" {
+		citeAttr = Fmt(" cite=\"%s\"", Convert(q.SourceHref).EscapeAttr())
+	}
+
+	// Build caption: "Author, Source"
+	caption := ""
+	if q.Author != "" {
+		caption = Fmt("<span class=\"quote-author\">%s</span>", Convert(q.Author).EscapeHTML())
+	}
+	if q.Source != "" {
+		source := Convert(q.Source).EscapeHTML()
+		if q.SourceHref != "" {
+			source = Fmt("<a href=\"%s\">%s</a>", Convert(q.SourceHref).EscapeAttr(), source)
+		}
+		if caption != "" {
+			caption += ", "
+		}
+		caption += Fmt("<cite>%s</cite>", source)
+	}
+	captionHTML := ""
+	if caption != "" {
+		captionHTML = Fmt("    <figcaption class=\"text text-sm\">%s</figcaption>\n", caption)
+	}
+
+	return Fmt(`<figure class="%s">
+    <blockquote%s>
+        <p>%s</p>
+    </blockquote>
+%s</figure>
+`, classEsc, citeAttr, Convert(q.Text).EscapeHTML(), captionHTML)
+}
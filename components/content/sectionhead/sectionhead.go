@@ -42,16 +42,16 @@ func (s *SectionHead) RenderHTML() string {
 	// Build border line
 	borderHTML := ""
 	if s.ShowBorder {
-		borderHTML = "    <div class=\"border-line\"></div>\n"
+		borderHTML = "    <div class=\"border-line\" aria-hidden=\"true\"></div>\n"
 	}
 
 	// Build line art
 	lineArtHTML := ""
 	if s.ShowLineArt {
 		dotsImgEsc := Convert(s.DotsImageSrc).EscapeAttr()
-		lineArtHTML = Fmt(`    <div class="line-art flex">
+		lineArtHTML = Fmt(`    <div class="line-art flex" aria-hidden="true">
         <div></div>
-        <img src="%s">
+        <img src="%s" alt="" role="presentation">
         <div></div>
     </div>
 `, dotsImgEsc)
@@ -9,7 +9,8 @@ import (
 type ServiceCard struct {
 	Title       string
 	Description string
-	IconSrc     string
+	IconSrc     string // Decorative icon; the title names the service
+	Reveal      bool   // Fade in on scroll with gosite.Config.ScrollReveal
 	CSSClass    string
 }
 
@@ -25,14 +26,19 @@ func (s *ServiceCard) RenderHTML() string {
 	titleEsc := Convert(s.Title).EscapeHTML()
 	descriptionEsc := Convert(s.Description).EscapeHTML()
 
-	tpl := `    <article class="%s">
+	reveal := ""
+	if s.Reveal {
+		reveal = " data-reveal"
+	}
+
+	tpl := `    <article class="%s"%s>
         <div class="icon">
-            <img src="%s">
+            <img src="%s" alt="" role="presentation">
         </div>
         <h3>%s</h3>
         <p class="text text-sm">%s</p>
     </article>
 `
 
-	return Fmt(tpl, classEsc, imageSrcEsc, titleEsc, descriptionEsc)
+	return Fmt(tpl, classEsc, reveal, imageSrcEsc, titleEsc, descriptionEsc)
 }
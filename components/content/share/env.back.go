@@ -0,0 +1,16 @@
+//go:build !wasm
+// +build !wasm
+
+package share
+
+import (
+	_ "embed"
+)
+
+//go:embed style.css
+var styleCss string
+
+// RenderCSS returns the CSS for the share buttons.
+func (s *Share) RenderCSS() string {
+	return styleCss
+}
@@ -0,0 +1,100 @@
+package share
+
+import (
+	"github.com/cdvelop/gosite/components/i18n"
+	. "github.com/cdvelop/tinystring"
+)
+
+// Networks supported by Share.
+const (
+	Twitter  = "twitter"
+	Facebook = "facebook"
+	LinkedIn = "linkedin"
+	WhatsApp = "whatsapp"
+	Email    = "email"
+)
+
+// defaultNetworks are rendered when Share.Networks is empty.
+var defaultNetworks = []string{Twitter, Facebook, LinkedIn, WhatsApp, Email}
+
+// networkLabels are the visible link texts.
+var networkLabels = map[string]string{
+	Twitter:  "Twitter",
+	Facebook: "Facebook",
+	LinkedIn: "LinkedIn",
+	WhatsApp: "WhatsApp",
+	Email:    "Email",
+}
+
+// Share implements HTMLRenderer and CSSRenderer interfaces.
+// It renders a row of share links for the given page URL and title.
+type Share struct {
+	URL      string   // absolute URL of the shared page
+	Title    string   // shared text / email subject
+	Networks []string // subset of Twitter, Facebook, LinkedIn, WhatsApp, Email; all when empty
+	CSSClass string
+}
+
+// RenderHTML generates the HTML for the share buttons. Unknown networks are skipped.
+func (s *Share) RenderHTML() string {
+	class := "share"
+	if s.CSSClass != "" {
+		class += " " + s.CSSClass
+	}
+
+	networks := s.Networks
+	if len(networks) == 0 {
+		networks = defaultNetworks
+	}
+
+	links := ""
+	for _, network := range networks {
+		href := shareURL(network, queryEscape(s.URL), queryEscape(s.Title))
+		if href == "" {
+			continue
+		}
+		target := ` target="_blank" rel="noopener noreferrer"`
+		if network == Email {
+			target = ""
+		}
+		links += Fmt("  <a class=\"share-link share-%s\" href=\"%s\"%s>%s</a>\n",
+			network, Convert(href).EscapeAttr(), target, networkLabels[network])
+	}
+
+	label := Translate(i18n.D.Share).EscapeAttr()
+	return Fmt("<div class=\"%s\" role=\"group\" aria-label=\"%s\">\n%s</div>\n", Convert(class).EscapeAttr(), label, links)
+}
+
+// shareURL returns the share intent of network for an already escaped URL
+// and title, or "" for an unknown network.
+func shareURL(network, u, title string) string {
+	switch network {
+	case Twitter:
+		return "https://twitter.com/intent/tweet?url=" + u + "&text=" + title
+	case Facebook:
+		return "https://www.facebook.com/sharer/sharer.php?u=" + u
+	case LinkedIn:
+		return "https://www.linkedin.com/sharing/share-offsite/?url=" + u
+	case WhatsApp:
+		return "https://wa.me/?text=" + title + "%20" + u
+	case Email:
+		return "mailto:?subject=" + title + "&body=" + u
+	}
+	return ""
+}
+
+// queryEscape percent-encodes s for use as a query value, leaving only the
+// unreserved characters (RFC 3986) as they are; spaces become %20.
+func queryEscape(s string) string {
+	const hex = "0123456789ABCDEF"
+	out := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			out = append(out, c)
+			continue
+		}
+		out = append(out, '%', hex[c>>4], hex[c&15])
+	}
+	return string(out)
+}
@@ -1,14 +1,19 @@
 package contactform
 
 import (
+	"github.com/cdvelop/gosite/components/content/mapembed"
+	"github.com/cdvelop/gosite/components/i18n"
 	. "github.com/cdvelop/tinystring"
 )
 
-// ContactForm implements HTMLRenderer and CSSRenderer interfaces.
-// It provides a contact form with optional embedded map.
+// ContactForm implements HTMLRenderer, CSSRenderer and JSRenderer interfaces.
+// It provides a contact form with optional embedded map. The form posts the
+// fields "name", "email" and "message" to Action.
 type ContactForm struct {
 	Title       string
 	Description string
+	Action      string // URL the form submits to
+	Method      string // HTTP method (default "post")
 	MapEmbedURL string
 	ShowMap     bool
 	BgColor     string
@@ -32,13 +37,24 @@ func (c *ContactForm) RenderHTML() string {
 	titleEsc := Convert(c.Title).EscapeHTML()
 	descEsc := Convert(c.Description).EscapeHTML()
 
+	method := c.Method
+	if method == "" {
+		method = "post"
+	}
+	actionEsc := Convert(c.Action).EscapeAttr()
+	methodEsc := Convert(method).EscapeAttr()
+
+	namePlaceholder := Translate(i18n.D.YourName).EscapeAttr()
+	emailPlaceholder := Translate(i18n.D.YourEmail).EscapeAttr()
+	messagePlaceholder := Translate(i18n.D.YourMessage).EscapeAttr()
+	submitLabel := Translate(i18n.D.SendMessage).EscapeHTML()
+
 	mapHTML := ""
-	if c.ShowMap && c.MapEmbedURL != "" {
-		mapURLEsc := Convert(c.MapEmbedURL).EscapeAttr()
+	if m := c.mapEmbed(); m != nil {
 		mapHTML = Fmt(`            <div class="contact-left">
-                <iframe src="%s" width="600" height="450" style="border:0;" allowfullscreen="" loading="lazy"></iframe>
+                %s
             </div>
-`, mapURLEsc)
+`, m.RenderHTML())
 	}
 
 	tpl := `    <section class="%s">
@@ -48,18 +64,18 @@ func (c *ContactForm) RenderHTML() string {
                     <h3 class="lead">%s</h3>
                     <p class="text text-md">%s</p>
                 </div>
-                <form>
+                <form action="%s" method="%s">
                     <div class="form-element">
-                        <input type="text" class="form-control" placeholder="Your name">
+                        <input type="text" name="name" class="form-control" placeholder="%s" required>
                     </div>
                     <div class="form-element">
-                        <input type="email" class="form-control" placeholder="Your email">
+                        <input type="email" name="email" class="form-control" placeholder="%s" required>
                     </div>
                     <div class="form-element">
-                        <textarea rows="5" placeholder="Your Message" class="form-control"></textarea>
+                        <textarea name="message" rows="5" placeholder="%s" class="form-control" required></textarea>
                     </div>
                     <button type="submit" class="btn btn-white btn-submit">
-                        <i class="fas fa-arrow-right"></i> Send Message
+                        <svg class="btn-icon" viewBox="0 0 24 24" width="24" height="24" fill="currentColor" aria-hidden="true" focusable="false"><path d="M12 4l-1.41 1.41L16.17 11H4v2h12.17l-5.58 5.59L12 20l8-8z"/></svg> %s
                     </button>
                 </form>
             </div>
@@ -67,5 +83,22 @@ func (c *ContactForm) RenderHTML() string {
     </section>
 `
 
-	return Fmt(tpl, classEsc, mapHTML, rightBgEsc, titleEsc, descEsc)
+	return Fmt(tpl, classEsc, mapHTML, rightBgEsc, titleEsc, descEsc, actionEsc, methodEsc,
+		namePlaceholder, emailPlaceholder, messagePlaceholder, submitLabel)
+}
+
+// mapEmbed returns the map shown next to the form, or nil when disabled.
+func (c *ContactForm) mapEmbed() *mapembed.MapEmbed {
+	if !c.ShowMap || c.MapEmbedURL == "" {
+		return nil
+	}
+	return &mapembed.MapEmbed{URL: c.MapEmbedURL}
+}
+
+// ChildComponents returns the map so its CSS is collected with the form.
+func (c *ContactForm) ChildComponents() []any {
+	if m := c.mapEmbed(); m != nil {
+		return []any{m}
+	}
+	return nil
 }
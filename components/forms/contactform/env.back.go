@@ -14,3 +14,11 @@ var styleCss string
 func (c *ContactForm) RenderCSS() string {
 	return styleCss
 }
+
+//go:embed script.js
+var scriptJs string
+
+// RenderJS returns the JavaScript for the contact form.
+func (c *ContactForm) RenderJS() string {
+	return scriptJs
+}
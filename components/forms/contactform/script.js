@@ -0,0 +1,22 @@
+// Component: ContactForm
+(function() {
+  const forms = document.querySelectorAll('.contact form');
+
+  forms.forEach(function(form) {
+    // Highlight invalid fields here; without JS the browser's native
+    // validation still applies.
+    form.noValidate = true;
+
+    form.addEventListener('submit', function(e) {
+      let valid = true;
+      Array.prototype.forEach.call(form.elements, function(field) {
+        if (!field.willValidate) return;
+        const invalid = !field.checkValidity() || (field.required && !field.value.trim());
+        field.classList.toggle('invalid', invalid);
+        if (invalid) valid = false;
+      });
+
+      if (!valid) e.preventDefault();
+    });
+  });
+})();
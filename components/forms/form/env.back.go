@@ -0,0 +1,24 @@
+//go:build !wasm
+// +build !wasm
+
+package form
+
+import (
+	_ "embed"
+)
+
+//go:embed style.css
+var styleCss string
+
+// RenderCSS returns the CSS for the form.
+func (f *Form) RenderCSS() string {
+	return styleCss
+}
+
+//go:embed script.js
+var scriptJs string
+
+// RenderJS returns the JavaScript for the form.
+func (f *Form) RenderJS() string {
+	return scriptJs
+}
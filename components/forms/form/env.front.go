@@ -0,0 +1,34 @@
+//go:build wasm
+// +build wasm
+
+package form
+
+import (
+	"syscall/js"
+
+	"github.com/cdvelop/gosite"
+)
+
+// BindEvents highlights the field being edited while it is invalid, replacing
+// the validation of RenderJS in the frontend (WASM). Native browser validation
+// still blocks invalid submits. It needs Config.ID to find the element.
+func (f *Form) BindEvents(b gosite.EventBinder) {
+	if f.Config.ID == "" {
+		return
+	}
+	doc := js.Global().Get("document")
+	form := doc.Call("getElementById", f.Config.ID)
+	if form.IsNull() {
+		return
+	}
+
+	b.EventListener(true, f.Config.ID, "input", func() {
+		// The listener has no event argument: the focused element is the one edited.
+		field := doc.Get("activeElement")
+		if field.IsNull() || !field.Get("form").Equal(form) || !field.Get("willValidate").Bool() {
+			return
+		}
+		invalid := !field.Call("checkValidity").Bool()
+		field.Get("classList").Call("toggle", "invalid", invalid)
+	})
+}
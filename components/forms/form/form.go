@@ -1,92 +1,197 @@
 package form
 
 import (
+	"github.com/cdvelop/gosite/components/i18n"
 	. "github.com/cdvelop/tinystring"
 )
 
 // Field defines a single field within a form.
 type Field struct {
-	Type        string
+	Type        string // Input type (e.g. "email", "tel") or "textarea"; unknown types fall back to "text"
 	Name        string
 	Placeholder string
 	Required    bool
+	Pattern     string   // Regular expression the value must match
+	Min         string   // Minimum value for number/date/time inputs
+	Max         string   // Maximum value for number/date/time inputs
+	MinLength   int      // Minimum number of characters (0 = unset)
+	MaxLength   int      // Maximum number of characters (0 = unset)
+	Options     []Option // Choices for Type "select"
 }
 
+// Option is a single choice of a select field.
+type Option struct {
+	Value string
+	Label string
+}
+
+// inputTypes lists the input types rendered as given.
+var inputTypes = map[string]bool{
+	"text": true, "email": true, "tel": true, "url": true, "number": true,
+	"password": true, "search": true, "date": true, "time": true,
+	"datetime-local": true, "range": true, "color": true, "hidden": true,
+}
+
+// inputModes selects the virtual keyboard for types that benefit from it.
+var inputModes = map[string]string{
+	"email": "email",
+	"tel":   "tel",
+	"url":   "url",
+}
+
+// Submit modes for Config.SubmitMode.
+const (
+	SubmitStandard = "standard" // Regular browser form submission (default)
+	SubmitAjax     = "ajax"     // POST via fetch and show an inline status message
+)
+
+// CSRFTemplateToken is the placeholder emitted by CSRFTemplate.
+const CSRFTemplateToken = "{{.CSRFToken}}"
+
+// CSRFTemplate is a Config.CSRFTokenFunc for pages served through Go's
+// html/template: the generated file keeps CSRFTemplateToken as the field value
+// and the server fills it per request by executing the page with a CSRFToken field.
+func CSRFTemplate() string { return CSRFTemplateToken }
+
 // Config holds the configuration for a form.
 type Config struct {
-	Action string
-	Method string
-	Fields []Field
+	ID             string // Element id; required to bind the frontend (WASM) behavior
+	Action         string
+	Method         string
+	Fields         []Field
+	SubmitMode     string // SubmitStandard (default) or SubmitAjax
+	SuccessMessage string // Ajax mode: shown after a successful submit
+	ErrorMessage   string // Ajax mode: shown when the submit fails
+
+	// CSRFTokenFunc, when set, adds a hidden "csrf_token" input whose value is
+	// the result of calling it at render time. Since pages are generated
+	// statically, a token obtained this way is baked into the file; use
+	// CSRFTemplate to emit a placeholder filled by the server instead.
+	CSRFTokenFunc func() string
 }
 
 // Form implements HTMLRenderer, CSSRenderer, and JSRenderer interfaces.
 type Form struct {
 	Config Config
+	inputs []fieldRenderer // formPlatform fields built by FromStruct, after Config.Fields
+}
+
+// fieldRenderer is a field rendered by its own package, e.g. formPlatform.
+type fieldRenderer interface {
+	RenderHTML() string
+}
+
+// ChildComponents returns the fields built by FromStruct so their CSS is
+// collected with the form.
+func (f *Form) ChildComponents() []any {
+	children := make([]any, len(f.inputs))
+	for i, in := range f.inputs {
+		children[i] = in
+	}
+	return children
 }
 
 // RenderHTML generates the HTML for the form.
 func (f *Form) RenderHTML() string {
 	// Build fields HTML
 	fields := ""
+	if f.Config.CSRFTokenFunc != nil {
+		token := Convert(f.Config.CSRFTokenFunc()).EscapeAttr()
+		fields += Fmt("  <input type=\"hidden\" name=\"csrf_token\" value=\"%s\">\n", token)
+	}
 	for _, field := range f.Config.Fields {
-		name := Convert(field.Name).EscapeAttr()
-		placeholder := Convert(field.Placeholder).EscapeAttr()
-		if field.Type == "textarea" {
-			req := boolAttr("required", field.Required)
-			fields += Fmt("  <textarea name=\"%s\" placeholder=\"%s\"%s></textarea>\n", name, placeholder, req)
-		} else {
-			t := Convert(field.Type).EscapeAttr()
-			req := boolAttr("required", field.Required)
-			fields += Fmt("  <input type=\"%s\" name=\"%s\" placeholder=\"%s\"%s>\n", t, name, placeholder, req)
-		}
+		fields += renderField(field)
+	}
+	for _, in := range f.inputs {
+		fields += "  " + Convert(in.RenderHTML()).TrimSpace().String() + "\n"
 	}
 
+	id := strAttr("id", f.Config.ID)
 	action := Convert(f.Config.Action).EscapeAttr()
 	method := Convert(f.Config.Method).EscapeAttr()
+	submit := Translate(i18n.D.SendMessage).EscapeHTML()
+
+	// Ajax mode: the script reads the messages from data attributes
+	ajaxAttrs := ""
+	statusHTML := ""
+	if f.Config.SubmitMode == SubmitAjax {
+		success := f.Config.SuccessMessage
+		if success == "" {
+			success = Translate(i18n.D.MessageSent).String()
+		}
+		failure := f.Config.ErrorMessage
+		if failure == "" {
+			failure = Translate(i18n.D.MessageNotSent).String()
+		}
+		ajaxAttrs = Fmt(" data-submit-mode=\"ajax\" data-success=\"%s\" data-error=\"%s\"",
+			Convert(success).EscapeAttr(), Convert(failure).EscapeAttr())
+		statusHTML = "  <p class=\"form-status\" role=\"status\" aria-live=\"polite\" hidden></p>\n"
+	}
 
-	tpl := `<form class="contact-form" action="%s" method="%s">
-%s  <button type="submit">Enviar Mensaje</button>
-</form>
+	tpl := `<form%s class="contact-form" action="%s" method="%s"%s>
+%s  <button type="submit">%s</button>
+%s</form>
 `
 
-	return Fmt(tpl, action, method, fields)
+	return Fmt(tpl, id, action, method, ajaxAttrs, fields, submit, statusHTML)
 }
 
-func boolAttr(attr string, val bool) string {
-	if val {
-		return " " + attr
+// renderField generates the HTML for a single field with its validation attributes.
+func renderField(field Field) string {
+	name := Convert(field.Name).EscapeAttr()
+	placeholder := Convert(field.Placeholder).EscapeAttr()
+	lengths := intAttr("minlength", field.MinLength) + intAttr("maxlength", field.MaxLength)
+	req := boolAttr("required", field.Required)
+
+	if field.Type == "textarea" {
+		return Fmt("  <textarea name=\"%s\" placeholder=\"%s\"%s%s></textarea>\n", name, placeholder, lengths, req)
 	}
-	return ""
-}
 
-// RenderCSS returns the CSS for the form.
-func (f *Form) RenderCSS() string {
-	return `.contact-form {
-  display: flex;
-  flex-direction: column;
-  gap: 1rem;
-  max-width: 500px;
-}
+	if field.Type == "select" {
+		options := ""
+		if field.Placeholder != "" {
+			// Empty value so a required select rejects the placeholder
+			options += Fmt("    <option value=\"\">%s</option>\n", Convert(field.Placeholder).EscapeHTML())
+		}
+		for _, opt := range field.Options {
+			options += Fmt("    <option value=\"%s\">%s</option>\n", Convert(opt.Value).EscapeAttr(), Convert(opt.Label).EscapeHTML())
+		}
+		return Fmt("  <select name=\"%s\"%s>\n%s  </select>\n", name, req, options)
+	}
+
+	typ := field.Type
+	if !inputTypes[typ] {
+		typ = "text"
+	}
+
+	attrs := strAttr("inputmode", inputModes[typ]) +
+		strAttr("pattern", field.Pattern) +
+		strAttr("min", field.Min) +
+		strAttr("max", field.Max) +
+		lengths
 
-.contact-form input,
-.contact-form textarea {
-  padding: 0.75rem;
-  border: 1px solid var(--color-border);
-  border-radius: 4px;
-  font-family: inherit;
+	return Fmt("  <input type=\"%s\" name=\"%s\" placeholder=\"%s\"%s%s>\n", typ, name, placeholder, attrs, req)
 }
 
-.contact-form button {
-  padding: 0.75rem 1.5rem;
-  background: var(--color-primary);
-  color: white;
-  border: none;
-  border-radius: 4px;
-  cursor: pointer;
+// strAttr returns ` attr="val"` with the value escaped, or "" when val is empty.
+func strAttr(attr, val string) string {
+	if val == "" {
+		return ""
+	}
+	return Fmt(" %s=\"%s\"", attr, Convert(val).EscapeAttr())
 }
 
-.contact-form button:hover {
-  opacity: 0.9;
+// intAttr returns ` attr="val"`, or "" when val is not positive.
+func intAttr(attr string, val int) string {
+	if val <= 0 {
+		return ""
+	}
+	return Fmt(" %s=\"%d\"", attr, val)
 }
-`
+
+func boolAttr(attr string, val bool) string {
+	if val {
+		return " " + attr
+	}
+	return ""
 }
@@ -0,0 +1,38 @@
+package form
+
+import (
+	"reflect"
+
+	"github.com/cdvelop/gosite/components/forms/formPlatform"
+	. "github.com/cdvelop/tinystring"
+)
+
+// FromStruct builds a form from the exported fields of a struct (or pointer
+// to struct) with formPlatform.NewField: each struct field name is converted
+// with SnakeLow and looked up in the formPlatform field dictionary, and its
+// `Input` tag is applied by SetPropertiesFromInputTag. Fields tagged
+// `Input:"-"` are skipped.
+func FromStruct(v any) (*Form, error) {
+	rt := reflect.TypeOf(v)
+	if rt != nil && rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	if rt == nil || rt.Kind() != reflect.Struct {
+		return nil, Err(D.Value, D.Type, D.Not, D.Valid, "FromStruct")
+	}
+
+	f := &Form{Config: Config{Method: "post"}}
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() || sf.Tag.Get("Input") == "-" {
+			continue
+		}
+
+		field, err := formPlatform.NewField(sf.Name, &sf)
+		if err != nil {
+			return nil, err
+		}
+		f.inputs = append(f.inputs, field)
+	}
+	return f, nil
+}
@@ -0,0 +1,51 @@
+// Component: Form
+(function() {
+  const forms = document.querySelectorAll('form.contact-form');
+
+  forms.forEach(function(form) {
+    // Validate here so invalid fields get highlighted; without JS the
+    // browser's native validation still applies.
+    form.noValidate = true;
+
+    form.addEventListener('submit', function(e) {
+      // Client-side validation: required, type, pattern, min/max and length
+      let valid = true;
+      Array.prototype.forEach.call(form.elements, function(field) {
+        if (!field.willValidate) return;
+        const invalid = !field.checkValidity() || (field.required && !field.value.trim());
+        field.classList.toggle('invalid', invalid);
+        if (invalid) valid = false;
+      });
+
+      if (!valid) {
+        e.preventDefault();
+        return;
+      }
+
+      // Standard mode: let the browser submit normally
+      if (form.dataset.submitMode !== 'ajax') return;
+
+      e.preventDefault();
+      const status = form.querySelector('.form-status');
+
+      fetch(form.action, {
+        method: 'POST',
+        body: new FormData(form),
+        headers: { 'Accept': 'application/json' }
+      }).then(function(response) {
+        if (!response.ok) throw new Error(response.status);
+        showStatus(status, form.dataset.success, false);
+        form.reset();
+      }).catch(function() {
+        showStatus(status, form.dataset.error, true);
+      });
+    });
+  });
+
+  function showStatus(el, message, isError) {
+    if (!el) return;
+    el.textContent = message;
+    el.classList.toggle('error', isError);
+    el.hidden = false;
+  }
+})();
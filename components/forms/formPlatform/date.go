@@ -0,0 +1,82 @@
+package formPlatform
+
+import (
+	"time"
+
+	. "github.com/cdvelop/tinystring"
+)
+
+// validateDate checks a DD-MM-YYYY value is a real calendar date within the
+// field Min/Max bounds (same format). birth_date rejects future dates by default.
+func (f *field) validateDate(value string) error {
+	date, err := parseDate(value)
+	if err != nil {
+		return err
+	}
+
+	if f.Min != "" {
+		if min, err := parseDate(f.Min); err == nil && date < min {
+			return Err(D.Date, D.Out, D.Of, D.Range, "min", f.Min)
+		}
+	}
+
+	if f.Max != "" {
+		if max, err := parseDate(f.Max); err == nil && date > max {
+			return Err(D.Date, D.Out, D.Of, D.Range, "max", f.Max)
+		}
+	} else if f.Name == "birth_date" {
+		y, m, d := time.Now().Date()
+		if date > y*10000+int(m)*100+d {
+			return Err(D.Date, D.Out, D.Of, D.Range)
+		}
+	}
+
+	return nil
+}
+
+// parseDate parses DD-MM-YYYY into a comparable YYYYMMDD number.
+func parseDate(value string) (int, error) {
+	errFormat := Err(D.Format, D.Date, D.Not, D.Valid, ": DD-MM-YYYY")
+
+	if len(value) != 10 || value[2] != '-' || value[5] != '-' {
+		return 0, errFormat
+	}
+	dd, mm, yyyy := value[0:2], value[3:5], value[6:10]
+	if !isDigits(dd) || !isDigits(mm) || !isDigits(yyyy) {
+		return 0, errFormat
+	}
+
+	day, _ := Convert(dd).Int()
+	month, _ := Convert(mm).Int()
+	year, _ := Convert(yyyy).Int()
+
+	if year == 0 || month < 1 || month > 12 || day < 1 || day > daysIn(month, year) {
+		return 0, Err(D.Date, D.Not, D.Valid, value)
+	}
+
+	return year*10000 + month*100 + day, nil
+}
+
+func daysIn(month, year int) int {
+	switch month {
+	case 2:
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 29
+		}
+		return 28
+	case 4, 6, 9, 11:
+		return 30
+	}
+	return 31
+}
+
+// validateDay checks a two-digit day of the month, "01" to "31".
+func validateDay(value string) error {
+	if !isDigits(value) {
+		return Err(D.Format, D.Not, D.Valid, value)
+	}
+	if day, _ := Convert(value).Int(); day < 1 || day > 31 {
+		return Err(D.Out, D.Of, D.Range, value)
+	}
+	return nil
+}
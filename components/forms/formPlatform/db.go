@@ -7,19 +7,51 @@ import (
 	. "github.com/cdvelop/tinystring"
 )
 
+// Dialect selects the SQL flavour emitted by CreateTableSQL.
+type Dialect string
+
 const (
-	dbFieldTypeInt    dbFieldType = "INT"
-	dbFieldTypeString dbFieldType = "VARCHAR(255)"
+	DialectDefault  Dialect = ""         // unquoted identifiers, no auto increment
+	DialectMySQL    Dialect = "mysql"    // `name`, AUTO_INCREMENT
+	DialectPostgres Dialect = "postgres" // "name", GENERATED BY DEFAULT AS IDENTITY
+	DialectSQLite   Dialect = "sqlite"   // "name", INTEGER PRIMARY KEY AUTOINCREMENT
 )
 
-type dbFieldType string
+// quote returns the identifier quoted for the dialect.
+func (d Dialect) quote(name string) string {
+	switch d {
+	case DialectMySQL:
+		return "`" + name + "`"
+	case DialectPostgres, DialectSQLite:
+		return `"` + name + `"`
+	}
+	return name
+}
+
+// CreateTableSQL returns the CREATE TABLE statement for the entity.
+// dialect is optional; without it the output uses DialectDefault.
+func (t entity) CreateTableSQL(dialect ...Dialect) string {
+	var d Dialect
+	if len(dialect) > 0 {
+		d = dialect[0]
+	}
 
-func (t entity) CreateTableSQL() string {
 	var sb = Convert()
-	sb.Write(Fmt("CREATE TABLE IF NOT EXISTS %s (\n", t.TableName))
+	sb.Write(Fmt("CREATE TABLE IF NOT EXISTS %s (\n", d.quote(t.TableName)))
 
 	for i, column := range t.Fields {
-		sb.Write(Fmt("    %s %s", column.Name, column.DbType))
+		autoIncrement := column.PrimaryKey && column.DbType == dbFieldTypeInt
+
+		dbType := column.DbType
+		if autoIncrement && d == DialectSQLite {
+			// sqlite only allows AUTOINCREMENT on INTEGER PRIMARY KEY
+			dbType = "INTEGER"
+		}
+		sb.Write(Fmt("    %s %s", d.quote(column.Name), string(dbType)))
+
+		if autoIncrement && d == DialectPostgres {
+			sb.Write(" GENERATED BY DEFAULT AS IDENTITY")
+		}
 
 		if column.Unique {
 			sb.Write(" UNIQUE")
@@ -27,8 +59,13 @@ func (t entity) CreateTableSQL() string {
 
 		if column.PrimaryKey {
 			sb.Write(" PRIMARY KEY")
-			if column.DbType == dbFieldTypeInt {
-				// sb.Write(" AUTO_INCREMENT")
+			if autoIncrement {
+				switch d {
+				case DialectMySQL:
+					sb.Write(" AUTO_INCREMENT")
+				case DialectSQLite:
+					sb.Write(" AUTOINCREMENT")
+				}
 			}
 		}
 
@@ -38,16 +75,59 @@ func (t entity) CreateTableSQL() string {
 
 		if column.ForeignKey != nil {
 			// CONSTRAINT fk_departments FOREIGN KEY (id_department) REFERENCES departments(id_department)
-			sb.Write(Fmt(",\n CONSTRAINT fk_%v FOREIGN KEY (%s) REFERENCES %s(%s) ON DELETE CASCADE",
-				column.ForeignKey.TableName, column.Name, column.ForeignKey.TableName, column.Name))
+			sb.Write(Fmt(",\n CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s(%s) ON DELETE CASCADE",
+				d.quote("fk_"+column.ForeignKey.TableName), d.quote(column.Name), d.quote(column.ForeignKey.TableName), d.quote(column.Name)))
+		}
+
+		if i < len(t.Fields)-1 || len(t.UniqueKeys) > 0 {
+			sb.Write(",")
 		}
+		sb.Write("\n")
+	}
 
-		if i < len(t.Fields)-1 {
+	for i, key := range t.UniqueKeys {
+		sb.Write(Fmt("    CONSTRAINT %s UNIQUE (%s)", d.quote(t.keyName("uq", key)), d.quoteList(key)))
+		if i < len(t.UniqueKeys)-1 {
 			sb.Write(",")
 		}
 		sb.Write("\n")
 	}
 
 	sb.Write(");")
+
+	// Indexes run after the table exists, in the same script
+	ifNotExists := " IF NOT EXISTS"
+	if d == DialectMySQL {
+		ifNotExists = "" // not supported by mysql
+	}
+	for _, column := range t.Fields {
+		if column.Index == 0 || column.Unique || column.PrimaryKey {
+			continue
+		}
+		name := t.keyName("idx", []string{column.Name})
+		sb.Write(Fmt("\nCREATE INDEX%s %s ON %s (%s);", ifNotExists, d.quote(name), d.quote(t.TableName), d.quote(column.Name)))
+	}
+
 	return sb.String()
 }
+
+// keyName builds a constraint or index name like uq_user_email_phone.
+func (t entity) keyName(prefix string, columns []string) string {
+	name := prefix + "_" + t.TableName
+	for _, c := range columns {
+		name += "_" + c
+	}
+	return name
+}
+
+// quoteList quotes each identifier and joins them with ", ".
+func (d Dialect) quoteList(names []string) string {
+	out := ""
+	for i, n := range names {
+		if i > 0 {
+			out += ", "
+		}
+		out += d.quote(n)
+	}
+	return out
+}
@@ -0,0 +1,89 @@
+//go:build !wasm
+
+package formPlatform
+
+import "testing"
+
+func TestCreateTableSQLDialects(t *testing.T) {
+	user := entity{
+		TableName: "user",
+		Fields: []field{
+			{Name: "id_user", DbType: dbFieldTypeInt, PrimaryKey: true},
+			{Name: "email", DbType: dbFieldTypeString, Unique: true},
+			{Name: "name", DbType: dbFieldTypeString, NotNull: true},
+		},
+	}
+
+	for _, tc := range []struct {
+		dialect []Dialect
+		want    string
+	}{
+		{nil, "CREATE TABLE IF NOT EXISTS user (\n" +
+			"    id_user INT PRIMARY KEY,\n" +
+			"    email VARCHAR(255) UNIQUE,\n" +
+			"    name VARCHAR(255) NOT NULL\n" +
+			");"},
+		{[]Dialect{DialectMySQL}, "CREATE TABLE IF NOT EXISTS `user` (\n" +
+			"    `id_user` INT PRIMARY KEY AUTO_INCREMENT,\n" +
+			"    `email` VARCHAR(255) UNIQUE,\n" +
+			"    `name` VARCHAR(255) NOT NULL\n" +
+			");"},
+		{[]Dialect{DialectPostgres}, "CREATE TABLE IF NOT EXISTS \"user\" (\n" +
+			"    \"id_user\" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,\n" +
+			"    \"email\" VARCHAR(255) UNIQUE,\n" +
+			"    \"name\" VARCHAR(255) NOT NULL\n" +
+			");"},
+		{[]Dialect{DialectSQLite}, "CREATE TABLE IF NOT EXISTS \"user\" (\n" +
+			"    \"id_user\" INTEGER PRIMARY KEY AUTOINCREMENT,\n" +
+			"    \"email\" VARCHAR(255) UNIQUE,\n" +
+			"    \"name\" VARCHAR(255) NOT NULL\n" +
+			");"},
+	} {
+		if got := user.CreateTableSQL(tc.dialect...); got != tc.want {
+			t.Errorf("CreateTableSQL(%v) =\n%s\nwant:\n%s", tc.dialect, got, tc.want)
+		}
+	}
+}
+
+func TestCreateTableSQLIndexesAndUniqueKeys(t *testing.T) {
+	member := entity{
+		TableName: "member",
+		Fields: []field{
+			{Name: "id_member", DbType: dbFieldTypeInt, PrimaryKey: true, Index: 1},
+			{Name: "email", DbType: dbFieldTypeString, Unique: true, Index: 2},
+			{Name: "id_company", DbType: dbFieldTypeInt, Index: 3},
+		},
+		UniqueKeys: [][]string{{"email", "id_company"}},
+	}
+
+	for _, tc := range []struct {
+		dialect []Dialect
+		want    string
+	}{
+		{nil, "CREATE TABLE IF NOT EXISTS member (\n" +
+			"    id_member INT PRIMARY KEY,\n" +
+			"    email VARCHAR(255) UNIQUE,\n" +
+			"    id_company INT,\n" +
+			"    CONSTRAINT uq_member_email_id_company UNIQUE (email, id_company)\n" +
+			");\n" +
+			"CREATE INDEX IF NOT EXISTS idx_member_id_company ON member (id_company);"},
+		{[]Dialect{DialectMySQL}, "CREATE TABLE IF NOT EXISTS `member` (\n" +
+			"    `id_member` INT PRIMARY KEY AUTO_INCREMENT,\n" +
+			"    `email` VARCHAR(255) UNIQUE,\n" +
+			"    `id_company` INT,\n" +
+			"    CONSTRAINT `uq_member_email_id_company` UNIQUE (`email`, `id_company`)\n" +
+			");\n" +
+			"CREATE INDEX `idx_member_id_company` ON `member` (`id_company`);"},
+		{[]Dialect{DialectPostgres}, "CREATE TABLE IF NOT EXISTS \"member\" (\n" +
+			"    \"id_member\" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,\n" +
+			"    \"email\" VARCHAR(255) UNIQUE,\n" +
+			"    \"id_company\" INT,\n" +
+			"    CONSTRAINT \"uq_member_email_id_company\" UNIQUE (\"email\", \"id_company\")\n" +
+			");\n" +
+			"CREATE INDEX IF NOT EXISTS \"idx_member_id_company\" ON \"member\" (\"id_company\");"},
+	} {
+		if got := member.CreateTableSQL(tc.dialect...); got != tc.want {
+			t.Errorf("CreateTableSQL(%v) =\n%s\nwant:\n%s", tc.dialect, got, tc.want)
+		}
+	}
+}
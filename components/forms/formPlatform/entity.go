@@ -7,6 +7,9 @@ type entity struct {
 	TableName string //table name db ej: user, product
 	// ParentStruct any
 	Fields []field
+	// UniqueKeys lists composite unique constraints, each one a set of field names
+	// eg: [][]string{{"email", "id_company"}}
+	UniqueKeys [][]string
 	// StructHandler *structHandler
 
 	HtmlForm string //html form
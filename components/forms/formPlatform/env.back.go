@@ -0,0 +1,16 @@
+//go:build !wasm
+// +build !wasm
+
+package formPlatform
+
+import (
+	_ "embed"
+)
+
+//go:embed style.css
+var styleCss string
+
+// RenderCSS returns the CSS for the form platform fields.
+func (f *field) RenderCSS() string {
+	return styleCss
+}
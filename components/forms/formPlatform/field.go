@@ -2,6 +2,15 @@ package formPlatform
 
 import "reflect"
 
+// dbFieldType is the SQL column type of a field, shared by both builds since
+// fields carry it; CreateTableSQL is backend only.
+type dbFieldType string
+
+const (
+	dbFieldTypeInt    dbFieldType = "INT"
+	dbFieldTypeString dbFieldType = "VARCHAR(255)"
+)
+
 type sourceData interface {
 	DataSource() any
 }
@@ -17,7 +26,7 @@ type fieldset struct {
 }
 
 type field struct {
-	Index  uint32 // index of the field
+	Index  uint32 // index of the field; when set CreateTableSQL also emits a CREATE INDEX
 	Legend string // e.g.: ID, Name, Phone
 
 	DbType     dbFieldType // e.g.: INT, VARCHAR(255)
@@ -47,6 +56,7 @@ type field struct {
 	Min                string              `ctx:"ui"` //valor mínimo
 	Max                string              `ctx:"ui"` //valor máximo
 	Maxlength          string              `ctx:"ui"` //ej: maxlength="12"
+	Pattern            string              `ctx:"ui"` //ej: pattern="[0-9]{7,11}"
 	Autocomplete       string              `ctx:"ui"`
 	Rows               string              `ctx:"ui"` //filas ej 4,5,6
 	Cols               string              `ctx:"ui"` //columnas ej 50,80
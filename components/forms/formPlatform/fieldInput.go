@@ -11,10 +11,16 @@ func NewField(name string, params ...any) (*field, error) {
 		Name: Convert(name).SnakeLow().String(),
 	}
 
-	// Extract typed parameters first
-	var remainingParams []any
+	// Extract typed parameters first; the rest are options like "!required"
+	var options []string
+	var dialCode *DialCode
+	var strength *PasswordStrength
 	for _, param := range params {
 		switch v := param.(type) {
+		case DialCode:
+			dialCode = &v
+		case PasswordStrength:
+			strength = &v
 		case uint32:
 			f.Index = v
 		case bool:
@@ -23,21 +29,22 @@ func NewField(name string, params ...any) (*field, error) {
 			f.ForeignKey = v
 		case *reflect.StructField:
 			f.reflectStructField = v
+		case string:
+			options = append(options, v)
 		default:
-			remainingParams = append(remainingParams, param)
+			options = append(options, Convert(v).String())
 		}
 	}
-	params = remainingParams
 
 	switch f.Name {
-	case "date", "birth_date": // formato fecha: DD-MM-YYYY
-		f.htmlName = "date"
-		f.Title = `title="` + Translate(D.Format, D.Date, `: DD-MM-YYYY"`).String()
-		f.ExtraValidation = G.Date.DateExists
-
-	case "date_age":
-		f.htmlName = "date"
-		f.Title = `title="` + Translate(D.Format, D.Date, `: DD-MM-YYYY"`).String()
+	case "date", "birth_date", "date_age": // formato fecha: DD-MM-YYYY
+		// text input: a native date input only accepts and submits YYYY-MM-DD
+		f.htmlName = "text"
+		f.Title = Translate(D.Format, D.Date, ": DD-MM-YYYY").String()
+		f.PlaceHolder = "DD-MM-YYYY"
+		f.Pattern = "[0-9]{2}-[0-9]{2}-[0-9]{4}"
+		f.Maxlength = "10"
+		f.ExtraValidation = f.validateDate
 
 	case "day_word":
 		f.htmlName = "date"
@@ -55,6 +62,16 @@ func NewField(name string, params ...any) (*field, error) {
 			if len(parts) != 2 {
 				return Err(D.Format, D.Email, D.Not, D.Valid)
 			}
+			local, domain := parts[0], parts[1]
+
+			if local == "" || HasPrefix(local, ".") || HasSuffix(local, ".") || Contains(s, "..") {
+				return Err(D.Format, D.Email, D.Not, D.Valid)
+			}
+
+			// the domain needs at least one dot separating non-empty labels
+			if !Contains(domain, ".") || HasPrefix(domain, ".") || HasSuffix(domain, ".") {
+				return Err(D.Format, D.Email, D.Not, D.Valid)
+			}
 
 			return nil
 		}}
@@ -88,11 +105,11 @@ func NewField(name string, params ...any) (*field, error) {
 	case "ip":
 		f.htmlName = "text"
 		f.Title = Translate(D.Example, ": 192.168.0.8").String()
-		f.permitted = permitted{Letters: true, Numbers: true, Characters: []rune{'.', ':'}, Minimum: 7, Maximum: 39, ExtraValidation: func(value string) error {
+		f.permitted = permitted{Letters: true, Numbers: true, Characters: []rune{'.', ':'}, Minimum: 2, Maximum: 45, ExtraValidation: func(value string) error {
 			var ipV string
 
 			if value == "0.0.0.0" {
-				return Err(D.Example, "IP", D.Not, D.Allowed, ':', "0.0.0.0")
+				return Err(D.Example, "IP", D.Not, D.Allowed, "0.0.0.0")
 			}
 
 			if Contains(value, ":") { //IPv6
@@ -107,8 +124,8 @@ func NewField(name string, params ...any) (*field, error) {
 				return Err(D.Format, "IPv4", D.Not, D.Valid)
 			}
 
-			if ipV == ":" && len(part) != 8 {
-				return Err(D.Format, "IPv6", D.Not, D.Valid)
+			if ipV == ":" {
+				return validateIPv6(value)
 			}
 			return nil
 		}}
@@ -116,8 +133,7 @@ func NewField(name string, params ...any) (*field, error) {
 	case "month_day":
 		f.htmlName = "text"
 		f.permitted = permitted{Numbers: true, Minimum: 2, Maximum: 2, ExtraValidation: func(value string) error {
-			_, err := G.Date.ValidateDay(value)
-			return err
+			return validateDay(value)
 		}}
 
 	case "name", "text":
@@ -129,8 +145,19 @@ func NewField(name string, params ...any) (*field, error) {
 		f.permitted = permitted{Numbers: true, Minimum: 1, Maximum: 20}
 
 		if f.Name == "phone" {
-			f.Min = "7"
-			f.Max = "11"
+			// digit count limits, rendered as minlength/maxlength
+			f.htmlName = "tel"
+			f.Pattern = "[0-9]{7,11}"
+			f.permitted = permitted{Numbers: true, Minimum: 7, Maximum: 11}
+
+			if dialCode != nil { // international mode eg: +56 912345678
+				code := *dialCode
+				f.Pattern = ""
+				f.PlaceHolder = "ej: +" + code.Code + " " + Convert("9").Repeat(code.Digits).String()
+				f.permitted = permitted{Numbers: true, Characters: []rune{'+', ' '},
+					Minimum: code.Digits, Maximum: len(code.Code) + code.Digits + 3,
+					ExtraValidation: code.validate}
+			}
 		}
 
 	case "password":
@@ -138,9 +165,13 @@ func NewField(name string, params ...any) (*field, error) {
 			Letters: true, Tilde: true, Numbers: true,
 			Characters: []rune{' ', '#', '%', '?', '.', ',', '-', '_'}, Minimum: 5, Maximum: 50}
 
+		if strength != nil {
+			f.ExtraValidation = strength.validate
+		}
+
 	case "rut":
 		f.htmlName = "text"
-		f.Autocomplete = `autocomplete="off"`
+		f.Autocomplete = "off"
 		f.Title = "rut sin puntos y con guion ej.: 11222333-4"
 		f.Class = []className{"rut"}
 
@@ -151,22 +182,8 @@ func NewField(name string, params ...any) (*field, error) {
 				if !Contains(value, "-") {
 					return Err(D.Hyphen, D.Not, D.Found)
 				}
-				data, onlyRun, err := runData(value)
-				if err != nil {
-					return err
-				}
-
-				if data[0][0:1] == "0" {
-					return Err(D.Not, D.Begin, D.With, D.Digit, 0)
-				}
-
-				dv := G.Rut.DvRut(onlyRun)
-
-				expectedDV := Convert(data[1]).ToLower().String()
-				if dv != expectedDV {
-					return Err(D.Digit, D.Checker, expectedDV, D.Not, D.Valid)
-				}
-				return nil
+				_, err := UnformatRut(value)
+				return err
 			},
 		}
 
@@ -203,10 +220,10 @@ func NewField(name string, params ...any) (*field, error) {
 		f.htmlName = "search"
 		f.permitted = permitted{Letters: true, Tilde: false, Numbers: true, Characters: []rune{'-', ' '}, Minimum: 2, Maximum: 20}
 	default:
-		return nil, Err(D.Field, ':', name, D.Not, D.Found, D.In, D.Dictionary)
+		return nil, Err(D.Field, f.Name, D.Not, D.Found, D.In, D.Dictionary)
 	}
 
-	f.SetPropertiesFromInputTag(params...)
+	f.SetPropertiesFromInputTag(options...)
 
 	return f, nil
 }
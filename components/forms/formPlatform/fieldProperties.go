@@ -4,26 +4,27 @@ import (
 	. "github.com/cdvelop/tinystring"
 )
 
+// SetPropertiesFromInputTag applies the options given to NewField and those of
+// the struct field `Input` tag. The tag has the form `type(option,option...)`,
+// eg: `Input:"textarea(placeholder=Your message,maxlength=500,!required)"`;
+// a leading type replaces the html name chosen by the dictionary.
+// options= and data= take `key:label` pairs separated by ";",
+// eg: `Input:"radio(options=f:Female;m:Male)"`.
 func (f *field) SetPropertiesFromInputTag(params ...string) {
-	inputData := f.reflectStructField.Tag.Get("Input")
-	if inputData != "" {
-		// Get base params from input tag
-		params = append(params, getParams(inputData))
-
-		// Get input type from input tag
-		if inputTypeFromLabel := Convert(inputData).Split("(")[0]; inputTypeFromLabel != "" {
-			inputType = inputTypeFromLabel
+	if f.reflectStructField != nil {
+		if tag := f.reflectStructField.Tag.Get("Input"); tag != "" {
+			typ, options := parseInputTag(tag)
+			if typ != "" {
+				f.htmlName = typ
+			}
+			params = append(params, options...)
 		}
 	}
 	if f.customName == "" {
 		f.customName = f.htmlName
 	}
 
-	// f.searchDataSourceImplementation(params...)
-
-	options := f.separateOptions(params...)
-
-	for _, option := range options {
+	for _, option := range params {
 		switch option {
 		case "hidden":
 			f.htmlName = option
@@ -39,19 +40,21 @@ func (f *field) SetPropertiesFromInputTag(params ...string) {
 			f.Numbers = true
 		}
 
-		switch {
-
-		case Contains(option, "chars="):
-			f.Characters = []rune(extractValue(option, "chars"))
-
-		case Contains(option, "data="):
-			extractData(extractValue(option, "data"), &f.DataSet)
-
-		case Contains(option, "options="):
-			extractData(extractValue(option, "options"), &f.options)
+		key, value, ok := splitOption(option)
+		if !ok {
+			continue
+		}
 
-		case Contains(option, "class="):
-			newClass := className(extractValue(option, "class"))
+		switch key {
+		case "chars":
+			f.Characters = []rune(value)
+		case "data":
+			extractData(value, &f.DataSet)
+		case "options":
+			f.options = nil // the tag options replace those of the dictionary
+			extractData(value, &f.options)
+		case "class":
+			newClass := className(value)
 			exists := false
 			for _, class := range f.Class {
 				if class == newClass {
@@ -62,52 +65,38 @@ func (f *field) SetPropertiesFromInputTag(params ...string) {
 			if !exists {
 				f.Class = append(f.Class, newClass)
 			}
-
-		case Contains(option, "name="):
-			f.Name, _ = Convert(option).KV("name")
-
-		case Contains(option, "min="):
-			f.Min, _ = Convert(option).KV("min")
-
-		case Contains(option, "max="):
-			f.Max, _ = Convert(option).KV("max")
-
-		case Contains(option, "maxlength="):
-			f.Maxlength, _ = Convert(option).KV("maxlength")
-
-		case Contains(option, "placeholder="):
-			f.PlaceHolder, _ = Convert(option).KV("placeholder")
-
-		case Contains(option, "title="):
-			f.Title, _ = Convert(option).KV("title")
-
-		case Contains(option, "autocomplete="):
-			f.Autocomplete, _ = Convert(option).KV("autocomplete")
-
-		case Contains(option, "rows="):
-			f.Rows, _ = Convert(option).KV("rows")
-
-		case Contains(option, "cols="):
-			f.Cols, _ = Convert(option).KV("cols")
-
-		case Contains(option, "step="):
-			f.Step, _ = Convert(option).KV("step")
-
-		case Contains(option, "oninput="):
-			f.Oninput, _ = Convert(option).KV("oninput")
-
-		case Contains(option, "onkeyup="):
-			f.Onkeyup, _ = Convert(option).KV("onkeyup")
-
-		case Contains(option, "onchange="):
-			f.Onchange, _ = Convert(option).KV("onchange")
-
-		case Contains(option, "value="):
-			f.Value, _ = Convert(option).KV("value")
-
-		case Contains(option, "accept="):
-			f.Accept, _ = Convert(option).KV("accept")
-
+		case "name":
+			f.Name = value
+		case "min":
+			f.Min = value
+		case "max":
+			f.Max = value
+		case "maxlength":
+			f.Maxlength = value
+		case "pattern":
+			f.Pattern = value
+		case "placeholder":
+			f.PlaceHolder = value
+		case "title":
+			f.Title = value
+		case "autocomplete":
+			f.Autocomplete = value
+		case "rows":
+			f.Rows = value
+		case "cols":
+			f.Cols = value
+		case "step":
+			f.Step = value
+		case "oninput":
+			f.Oninput = value
+		case "onkeyup":
+			f.Onkeyup = value
+		case "onchange":
+			f.Onchange = value
+		case "value":
+			f.Value = value
+		case "accept":
+			f.Accept = value
 		}
 	}
 
@@ -136,3 +125,47 @@ func (f *field) SetPropertiesFromInputTag(params ...string) {
 		}
 	}
 }
+
+// parseInputTag splits an `Input` tag like "textarea(rows=4,!required)" into
+// its type and trimmed, non-empty options.
+func parseInputTag(tag string) (typ string, options []string) {
+	typ = tag
+	if open := Index(tag, "("); open >= 0 {
+		typ = tag[:open]
+		inner := tag[open+1:]
+		if HasSuffix(inner, ")") {
+			inner = inner[:len(inner)-1]
+		}
+		for _, option := range Convert(inner).Split(",") {
+			if option = Convert(option).TrimSpace().String(); option != "" {
+				options = append(options, option)
+			}
+		}
+	}
+	return Convert(typ).TrimSpace().String(), options
+}
+
+// splitOption splits "key=value" at the first "=".
+func splitOption(option string) (key, value string, ok bool) {
+	eq := Index(option, "=")
+	if eq < 0 {
+		return "", "", false
+	}
+	return option[:eq], option[eq+1:], true
+}
+
+// extractData appends one single-entry map per "key:label" pair of value,
+// pairs separated by ";", keeping their order. A pair without ":" uses the
+// key as label.
+func extractData(value string, target *[]map[string]string) {
+	for _, pair := range Convert(value).Split(";") {
+		if pair = Convert(pair).TrimSpace().String(); pair == "" {
+			continue
+		}
+		key, label := pair, pair
+		if colon := Index(pair, ":"); colon >= 0 {
+			key, label = pair[:colon], pair[colon+1:]
+		}
+		*target = append(*target, map[string]string{key: label})
+	}
+}
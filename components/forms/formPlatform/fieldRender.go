@@ -0,0 +1,163 @@
+package formPlatform
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// RenderHTML generates the <input>, <select> or <textarea> for the field,
+// including the attributes derived from its permitted rules.
+func (f *field) RenderHTML() string {
+	switch f.htmlName {
+	case "textarea":
+		return Fmt("<textarea%s>%s</textarea>", f.attributes(), Convert(f.Value).EscapeHTML())
+
+	case "select":
+		return Fmt("<select%s>\n%s</select>", f.attributes(), f.renderOptions())
+
+	case "radio":
+		return f.renderRadios()
+	}
+
+	return Fmt("<input type=\"%s\"%s%s>", f.inputType(), f.attributes(), attr("value", f.Value))
+}
+
+// inputType maps the field html name to a valid input type.
+func (f *field) inputType() string {
+	switch f.htmlName {
+	case "mail":
+		return "email"
+	case "":
+		if f.Name == "password" {
+			return "password"
+		}
+		return "text"
+	}
+	return f.htmlName
+}
+
+// attributes returns the shared attributes of the field element, each with a
+// leading space.
+func (f *field) attributes() string {
+	b := Convert()
+
+	b.Write(attr("id", f.Id))
+	b.Write(attr("name", f.Name))
+	b.Write(attr("class", f.classNames()))
+	b.Write(attr("title", f.Title))
+	b.Write(attr("placeholder", f.PlaceHolder))
+	b.Write(attr("autocomplete", f.Autocomplete))
+	b.Write(attr("pattern", f.Pattern))
+
+	if f.htmlName == "number" || f.htmlName == "date" || f.htmlName == "time" {
+		b.Write(attr("min", f.Min))
+		b.Write(attr("max", f.Max))
+		b.Write(attr("step", f.Step))
+	} else if f.htmlName != "hidden" {
+		if f.Minimum > 0 {
+			b.Write(attr("minlength", Convert(f.Minimum).String()))
+		}
+		maxlength := f.Maxlength
+		if maxlength == "" && f.Maximum > 0 {
+			maxlength = Convert(f.Maximum).String()
+		}
+		b.Write(attr("maxlength", maxlength))
+	}
+
+	if f.htmlName == "textarea" {
+		b.Write(attr("rows", f.Rows))
+		b.Write(attr("cols", f.Cols))
+	}
+
+	b.Write(attr("accept", f.Accept))
+	b.Write(attr("oninput", f.Oninput))
+	b.Write(attr("onkeyup", f.Onkeyup))
+	b.Write(attr("onchange", f.Onchange))
+
+	for _, data := range f.DataSet {
+		for _, key := range sortedKeys(data) {
+			b.Write(Fmt(" data-%s=\"%s\"", Convert(key).EscapeAttr(), Convert(data[key]).EscapeAttr()))
+		}
+	}
+
+	if f.Multiple != "" {
+		b.Write(" multiple")
+	}
+	if !f.allowSkipCompleted && f.htmlName != "hidden" {
+		b.Write(" required")
+	}
+
+	return b.String()
+}
+
+func (f *field) classNames() string {
+	b := Convert()
+	for i, class := range f.Class {
+		if i > 0 {
+			b.Write(" ")
+		}
+		b.Write(string(class))
+	}
+	return b.String()
+}
+
+func (f *field) renderOptions() string {
+	b := Convert()
+	for _, option := range f.options {
+		for _, key := range sortedKeys(option) {
+			selected := ""
+			if key != "" && key == f.Value {
+				selected = " selected"
+			}
+			b.Write(Fmt("  <option value=\"%s\"%s>%s</option>\n",
+				Convert(key).EscapeAttr(), selected, Convert(option[key]).EscapeHTML()))
+		}
+	}
+	return b.String()
+}
+
+// renderRadios renders one labelled radio input per option sharing the field name.
+func (f *field) renderRadios() string {
+	name := Convert(f.Name).EscapeAttr()
+	required := ""
+	if !f.allowSkipCompleted {
+		required = " required"
+	}
+
+	b := Convert()
+	for _, option := range f.options {
+		for _, key := range sortedKeys(option) {
+			if key == "" {
+				continue
+			}
+			checked := ""
+			if key == f.Value {
+				checked = " checked"
+			}
+			b.Write(Fmt("<label><input type=\"radio\" name=\"%s\" value=\"%s\"%s%s> %s</label>\n",
+				name, Convert(key).EscapeAttr(), checked, required, Convert(option[key]).EscapeHTML()))
+		}
+	}
+	return b.String()
+}
+
+// attr returns ` name="value"` with the value escaped, or "" when value is empty.
+func attr(name, value string) string {
+	if value == "" {
+		return ""
+	}
+	return Fmt(" %s=\"%s\"", name, Convert(value).EscapeAttr())
+}
+
+// sortedKeys returns the keys of m in ascending order so the output is stable.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	for i := 1; i < len(keys); i++ {
+		for j := i; j > 0 && keys[j] < keys[j-1]; j-- {
+			keys[j], keys[j-1] = keys[j-1], keys[j]
+		}
+	}
+	return keys
+}
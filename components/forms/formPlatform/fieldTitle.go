@@ -30,7 +30,7 @@ func (f *field) setDynamicTitle() {
 				chars = append(chars, string(char))
 			}
 		}
-		parts = append(parts, Translate(D.Chars, chars).String())
+		parts = append(parts, Translate(D.Chars, Convert(chars).Join(" ").String()).String())
 	}
 
 	if f.Minimum != 0 {
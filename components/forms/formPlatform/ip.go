@@ -0,0 +1,77 @@
+package formPlatform
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// validateIPv6 accepts full, "::" compressed and IPv4-embedded addresses
+// eg: 2001:db8:0:0:0:0:0:1, ::1, fe80::1, ::ffff:192.168.0.8
+func validateIPv6(value string) error {
+	errFormat := Err(D.Format, "IPv6", D.Not, D.Valid)
+
+	head, tail, compressed := value, "", false
+	if i := Index(value, "::"); i >= 0 {
+		head, tail, compressed = value[:i], value[i+2:], true
+		if Contains(tail, "::") {
+			return errFormat
+		}
+	}
+
+	var groups []string
+	if head != "" {
+		groups = append(groups, Convert(head).Split(":")...)
+	}
+	if tail != "" {
+		groups = append(groups, Convert(tail).Split(":")...)
+	}
+
+	count := len(groups)
+	for i, g := range groups {
+		// an embedded IPv4 is only allowed as the last part and counts as two groups
+		if i == len(groups)-1 && Contains(g, ".") {
+			if !isIPv4(g) {
+				return errFormat
+			}
+			count++
+			continue
+		}
+		if !isHexGroup(g) {
+			return errFormat
+		}
+	}
+
+	if (compressed && count > 7) || (!compressed && count != 8) {
+		return errFormat
+	}
+	return nil
+}
+
+// isHexGroup reports whether s has 1 to 4 hexadecimal digits.
+func isHexGroup(s string) bool {
+	if len(s) == 0 || len(s) > 4 {
+		return false
+	}
+	for _, c := range s {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// isIPv4 reports whether s is a dotted quad with octets 0-255.
+func isIPv4(s string) bool {
+	parts := Convert(s).Split(".")
+	if len(parts) != 4 {
+		return false
+	}
+	for _, p := range parts {
+		if len(p) == 0 || len(p) > 3 || !isDigits(p) {
+			return false
+		}
+		if n, err := Convert(p).Int(); err != nil || n > 255 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+package formPlatform
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// PasswordStrength enables strength rules on the password field when passed
+// to NewField, eg: NewField("password", PasswordStrength{Upper: 1, Digits: 1}).
+// Zero values keep the lenient default.
+type PasswordStrength struct {
+	Upper   int // minimum uppercase letters
+	Lower   int // minimum lowercase letters
+	Digits  int // minimum digits
+	Special int // minimum characters that are not letters, digits or spaces
+}
+
+// validate returns an error naming the first requirement not met.
+func (p PasswordStrength) validate(value string) error {
+	var upper, lower, digits, special int
+	for _, c := range value {
+		switch {
+		case c >= 'A' && c <= 'Z':
+			upper++
+		case c >= 'a' && c <= 'z':
+			lower++
+		case c >= '0' && c <= '9':
+			digits++
+		case c == ' ' || c > 127: // spaces and accented letters are not special
+		default:
+			special++
+		}
+	}
+
+	switch {
+	case upper < p.Upper:
+		return Err(D.Required, "min", Convert(p.Upper).String(), D.Letters, "A-Z")
+	case lower < p.Lower:
+		return Err(D.Required, "min", Convert(p.Lower).String(), D.Letters, "a-z")
+	case digits < p.Digits:
+		return Err(D.Required, "min", Convert(p.Digits).String(), D.Numbers, "0-9")
+	case special < p.Special:
+		return Err(D.Required, "min", Convert(p.Special).String(), D.Chars, "#%?.,-_")
+	}
+	return nil
+}
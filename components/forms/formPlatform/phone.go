@@ -0,0 +1,31 @@
+package formPlatform
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// DialCode enables the international mode of the phone field when passed to
+// NewField, eg: NewField("phone", DialCode{Code: "56", Digits: 9}).
+type DialCode struct {
+	Code   string // country calling code without "+", eg: 56
+	Digits int    // expected length of the national number, eg: 9
+}
+
+// validate accepts "+<code> <number>", ignoring spaces.
+func (d DialCode) validate(value string) error {
+	number := Convert(value).Replace(" ", "").String()
+
+	if !HasPrefix(number, "+"+d.Code) {
+		return Err(D.Number, D.Must, D.Begin, D.With, "+"+d.Code)
+	}
+	number = number[len(d.Code)+1:]
+
+	if !isDigits(number) {
+		return Err(D.Chars, D.Not, D.Allowed, D.In, D.Numbers)
+	}
+
+	if len(number) != d.Digits {
+		return Err(D.Number, D.Must, D.Be, Convert(d.Digits).String(), D.Digit)
+	}
+	return nil
+}
@@ -26,19 +26,12 @@ func (e *entity) FormRender(attributes ...string) string {
 		}
 	}
 
-	e.HtmlForm = `<form name="` + e.Name + `"` + class + autocomplete + spellcheck + `>
+	e.HtmlForm = `<form name="` + Convert(e.Name).EscapeAttr() + `"` + class + autocomplete + spellcheck + `>
 	
 	`
-	var tabIndex int
-	for _, f := range e.Fields {
-
-		if f.Input == nil {
-			continue
-		}
-
-		e.HtmlForm += f.Render(&tabIndex)
+	for i := range e.Fields {
+		e.HtmlForm += e.Fields[i].RenderHTML()
 		e.HtmlForm += "\n\n"
-		tabIndex++
 	}
 
 	e.HtmlForm += `
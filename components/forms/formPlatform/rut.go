@@ -44,3 +44,64 @@ func isDigits(s string) bool {
 	}
 	return true
 }
+
+// FormatRut validates a Chilean RUT and returns its canonical dotted form
+// eg: "11222333-4", "11.222.333-4" or "11222333 - 4" => "11.222.333-4"
+func FormatRut(value string) (string, error) {
+	clean, err := UnformatRut(value)
+	if err != nil {
+		return "", err
+	}
+
+	data := Convert(clean).Split("-")
+	number := data[0]
+
+	// group the number in thousands from the right
+	out := ""
+	for len(number) > 3 {
+		out = "." + number[len(number)-3:] + out
+		number = number[:len(number)-3]
+	}
+
+	return number + out + "-" + data[1], nil
+}
+
+// UnformatRut validates a Chilean RUT and returns it without dots or spaces
+// and with a lower case check digit eg: "11.222.333-K" => "11222333-k"
+func UnformatRut(value string) (string, error) {
+	clean := Convert(value).Replace(".", "").Replace(" ", "").ToLower().String()
+
+	data, onlyRun, err := runData(clean)
+	if err != nil {
+		return "", err
+	}
+
+	if data[0][0:1] == "0" {
+		return "", Err(D.Not, D.Begin, D.With, D.Digit, "0")
+	}
+
+	if dv := dvRut(onlyRun); dv != data[1] {
+		return "", Err(D.Digit, D.Checker, data[1], D.Not, D.Valid)
+	}
+
+	return clean, nil
+}
+
+// dvRut returns the modulo 11 check digit of a RUT number, "0"-"9" or "k".
+func dvRut(number int) string {
+	sum, factor := 0, 2
+	for ; number > 0; number /= 10 {
+		sum += number % 10 * factor
+		if factor++; factor > 7 {
+			factor = 2
+		}
+	}
+	switch dv := 11 - sum%11; dv {
+	case 11:
+		return "0"
+	case 10:
+		return "k"
+	default:
+		return Convert(dv).String()
+	}
+}
@@ -0,0 +1,53 @@
+package i18n
+
+import (
+	"github.com/cdvelop/tinystring"
+)
+
+// D holds the user-facing strings of the built-in components, using the same
+// horizontal format as tinystring.D so they are resolved with Translate and
+// follow the language selected through OutLang (gosite.Config.Lang).
+// Language order: EN, ES, ZH, HI, AR, PT, FR, DE, RU
+//
+// Usage: Translate(i18n.D.SendMessage).String()
+var D = struct {
+	BackToTop      tinystring.LocStr // "back to top"
+	CloseMenu      tinystring.LocStr // "close menu"
+	Contents       tinystring.LocStr // "contents"
+	Copied         tinystring.LocStr // "copied"
+	Copy           tinystring.LocStr // "copy"
+	Days           tinystring.LocStr // "days"
+	Hours          tinystring.LocStr // "hours"
+	Map            tinystring.LocStr // "map"
+	MessageNotSent tinystring.LocStr // "message could not be sent"
+	MessageSent    tinystring.LocStr // "message sent"
+	Minutes        tinystring.LocStr // "minutes"
+	OpenMenu       tinystring.LocStr // "open menu"
+	SearchHere     tinystring.LocStr // "search here"
+	Seconds        tinystring.LocStr // "seconds"
+	SendMessage    tinystring.LocStr // "send message"
+	Share          tinystring.LocStr // "share"
+	YourEmail      tinystring.LocStr // "your email"
+	YourMessage    tinystring.LocStr // "your message"
+	YourName       tinystring.LocStr // "your name"
+}{
+	tinystring.LocStr{"Back to top", "Volver arriba", "返回顶部", "ऊपर जाएँ", "العودة إلى الأعلى", "Voltar ao topo", "Retour en haut", "Nach oben", "Наверх"},
+	tinystring.LocStr{"Close menu", "Cerrar menú", "关闭菜单", "मेनू बंद करें", "إغلاق القائمة", "Fechar menu", "Fermer le menu", "Menü schließen", "Закрыть меню"},
+	tinystring.LocStr{"Contents", "Contenido", "目录", "विषय-सूची", "المحتويات", "Conteúdo", "Sommaire", "Inhalt", "Содержание"},
+	tinystring.LocStr{"Copied", "Copiado", "已复制", "कॉपी हो गया", "تم النسخ", "Copiado", "Copié", "Kopiert", "Скопировано"},
+	tinystring.LocStr{"Copy", "Copiar", "复制", "कॉपी करें", "نسخ", "Copiar", "Copier", "Kopieren", "Копировать"},
+	tinystring.LocStr{"Days", "Días", "天", "दिन", "أيام", "Dias", "Jours", "Tage", "Дни"},
+	tinystring.LocStr{"Hours", "Horas", "小时", "घंटे", "ساعات", "Horas", "Heures", "Stunden", "Часы"},
+	tinystring.LocStr{"Map", "Mapa", "地图", "मानचित्र", "خريطة", "Mapa", "Carte", "Karte", "Карта"},
+	tinystring.LocStr{"The message could not be sent", "No se pudo enviar el mensaje", "消息无法发送", "संदेश नहीं भेजा जा सका", "تعذر إرسال الرسالة", "Não foi possível enviar a mensagem", "Le message n'a pas pu être envoyé", "Die Nachricht konnte nicht gesendet werden", "Не удалось отправить сообщение"},
+	tinystring.LocStr{"Message sent", "Mensaje enviado", "消息已发送", "संदेश भेजा गया", "تم إرسال الرسالة", "Mensagem enviada", "Message envoyé", "Nachricht gesendet", "Сообщение отправлено"},
+	tinystring.LocStr{"Minutes", "Minutos", "分钟", "मिनट", "دقائق", "Minutos", "Minutes", "Minuten", "Минуты"},
+	tinystring.LocStr{"Open menu", "Abrir menú", "打开菜单", "मेनू खोलें", "فتح القائمة", "Abrir menu", "Ouvrir le menu", "Menü öffnen", "Открыть меню"},
+	tinystring.LocStr{"Search here", "Buscar aquí", "在此搜索", "यहाँ खोजें", "ابحث هنا", "Pesquisar aqui", "Rechercher ici", "Hier suchen", "Искать здесь"},
+	tinystring.LocStr{"Seconds", "Segundos", "秒", "सेकंड", "ثوانٍ", "Segundos", "Secondes", "Sekunden", "Секунды"},
+	tinystring.LocStr{"Send Message", "Enviar Mensaje", "发送消息", "संदेश भेजें", "إرسال رسالة", "Enviar Mensagem", "Envoyer le message", "Nachricht senden", "Отправить сообщение"},
+	tinystring.LocStr{"Share", "Compartir", "分享", "साझा करें", "مشاركة", "Compartilhar", "Partager", "Teilen", "Поделиться"},
+	tinystring.LocStr{"Your email", "Tu correo", "您的邮箱", "आपका ईमेल", "بريدك الإلكتروني", "Seu e-mail", "Votre e-mail", "Ihre E-Mail", "Ваш email"},
+	tinystring.LocStr{"Your message", "Tu mensaje", "您的留言", "आपका संदेश", "رسالتك", "Sua mensagem", "Votre message", "Ihre Nachricht", "Ваше сообщение"},
+	tinystring.LocStr{"Your name", "Tu nombre", "您的姓名", "आपका नाम", "اسمك", "Seu nome", "Votre nom", "Ihr Name", "Ваше имя"},
+}
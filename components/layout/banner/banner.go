@@ -27,6 +27,7 @@ type Banner struct {
 	Author     string
 	Text       string
 	ImageSrc   string
+	ImageAlt   string // Image alt text; required unless the image is decorative
 	Buttons    []Button
 	BgImageSrc string
 	CSSClass   string
@@ -50,13 +51,14 @@ func (b *Banner) RenderHTML() string {
 		quoteEsc := Convert(b.Quote).EscapeHTML()
 		authorEsc := Convert(b.Author).EscapeHTML()
 		content = Fmt(`        <div class="container text-white">
-            <blockquote class="lead"><i class="fas fa-quote-left"></i> %s <i class="fas fa-quote-right"></i></blockquote>
+            <blockquote class="lead">%s %s %s</blockquote>
             <small class="text text-sm">- %s</small>
         </div>
-`, quoteEsc, authorEsc)
+`, quoteIcon("quote-open"), quoteEsc, quoteIcon("quote-close"), authorEsc)
 	} else {
 		textEsc := Convert(b.Text).EscapeHTML()
 		imgSrcEsc := Convert(b.ImageSrc).EscapeAttr()
+		imgAltEsc := Convert(b.ImageAlt).EscapeAttr()
 
 		buttonsHTML := ""
 		for _, btn := range b.Buttons {
@@ -69,7 +71,7 @@ func (b *Banner) RenderHTML() string {
 
 		content = Fmt(`        <div class="container grid">
             <div class="banner-two-left">
-                <img src="%s">
+                <img src="%s" alt="%s">
             </div>
             <div class="banner-two-right">
                 <p class="lead text-white">%s</p>
@@ -77,7 +79,7 @@ func (b *Banner) RenderHTML() string {
 %s                </div>
             </div>
         </div>
-`, imgSrcEsc, textEsc, buttonsHTML)
+`, imgSrcEsc, imgAltEsc, textEsc, buttonsHTML)
 	}
 
 	tpl := `    <section class="%s">
@@ -86,3 +88,9 @@ func (b *Banner) RenderHTML() string {
 
 	return Fmt(tpl, classEsc, content)
 }
+
+// quoteIcon returns a decorative inline SVG quotation mark; "quote-open" is
+// drawn mirrored by the stylesheet.
+func quoteIcon(class string) string {
+	return Fmt(`<svg class="quote-icon %s" viewBox="0 0 24 24" width="24" height="24" fill="currentColor" aria-hidden="true" focusable="false"><path d="M6 17h3l2-4V7H5v6h3zm8 0h3l2-4V7h-6v6h3z"/></svg>`, class)
+}
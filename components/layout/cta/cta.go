@@ -0,0 +1,71 @@
+package cta
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// Button represents a button in the CTA band
+type Button struct {
+	Label    string
+	Href     string
+	CSSClass string // e.g., "btn-white", "btn-light-blue"
+}
+
+// CTA implements HTMLRenderer and CSSRenderer interfaces.
+// It provides a centered full-width call-to-action band, usually placed
+// right before the footer.
+type CTA struct {
+	Heading  string
+	Subtext  string
+	Buttons  []Button
+	BgColor  string // CSS class for background color (default "bg-blue")
+	Reveal   bool   // Fade in on scroll with gosite.Config.ScrollReveal
+	CSSClass string
+}
+
+// RenderHTML generates the HTML for the CTA band.
+func (c *CTA) RenderHTML() string {
+	class := "cta text-white text-center bg-blue"
+	if c.BgColor != "" {
+		class = "cta text-white text-center " + c.BgColor
+	}
+	if c.CSSClass != "" {
+		class += " " + c.CSSClass
+	}
+	classEsc := Convert(class).EscapeAttr()
+
+	headingEsc := Convert(c.Heading).EscapeHTML()
+
+	subtextHTML := ""
+	if c.Subtext != "" {
+		subtextHTML = Fmt("            <p class=\"text text-md\">%s</p>\n", Convert(c.Subtext).EscapeHTML())
+	}
+
+	buttonsHTML := ""
+	if len(c.Buttons) > 0 {
+		for _, btn := range c.Buttons {
+			labelEsc := Convert(btn.Label).EscapeHTML()
+			hrefEsc := Convert(btn.Href).EscapeAttr()
+			btnClassEsc := Convert("btn " + btn.CSSClass).EscapeAttr()
+			buttonsHTML += Fmt(`                <a href="%s" class="%s">%s</a>
+`, hrefEsc, btnClassEsc, labelEsc)
+		}
+		buttonsHTML = Fmt(`            <div class="btn-group">
+%s            </div>
+`, buttonsHTML)
+	}
+
+	reveal := ""
+	if c.Reveal {
+		reveal = " data-reveal"
+	}
+
+	tpl := `    <section class="%s"%s>
+        <div class="container">
+            <h2 class="lead">%s</h2>
+%s%s        </div>
+    </section>
+`
+
+	return Fmt(tpl, classEsc, reveal, headingEsc, subtextHTML, buttonsHTML)
+}
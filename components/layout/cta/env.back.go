@@ -0,0 +1,16 @@
+//go:build !wasm
+// +build !wasm
+
+package cta
+
+import (
+	_ "embed"
+)
+
+//go:embed style.css
+var styleCss string
+
+// RenderCSS returns the CSS for the CTA band.
+func (c *CTA) RenderCSS() string {
+	return styleCss
+}
@@ -0,0 +1,32 @@
+package divider
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// Divider implements HTMLRenderer and CSSRenderer interfaces.
+// It renders an <hr>, or with Label a separator with the text centered over
+// the line, e.g. "OR" between a form and social login buttons.
+type Divider struct {
+	Label    string
+	Style    string // Line style: "solid" (default), "dashed" or "dotted"
+	CSSClass string
+}
+
+// RenderHTML generates the HTML for the divider.
+func (d *Divider) RenderHTML() string {
+	class := "divider"
+	switch d.Style {
+	case "dashed", "dotted":
+		class += " divider-" + d.Style
+	}
+	if d.CSSClass != "" {
+		class += " " + d.CSSClass
+	}
+	classEsc := Convert(class).EscapeAttr()
+
+	if d.Label == "" {
+		return Fmt("<hr class=\"%s\">\n", classEsc)
+	}
+	return Fmt("<div class=\"%s divider-labeled\" role=\"separator\"><span class=\"text-sm\">%s</span></div>\n", classEsc, Convert(d.Label).EscapeHTML())
+}
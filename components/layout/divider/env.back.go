@@ -0,0 +1,16 @@
+//go:build !wasm
+// +build !wasm
+
+package divider
+
+import (
+	_ "embed"
+)
+
+//go:embed style.css
+var styleCss string
+
+// RenderCSS returns the CSS for the divider.
+func (d *Divider) RenderCSS() string {
+	return styleCss
+}
@@ -1,6 +1,9 @@
 package footer
 
 import (
+	"time"
+
+	"github.com/cdvelop/gosite/components/content/brand"
 	. "github.com/cdvelop/tinystring"
 )
 
@@ -15,6 +18,8 @@ type FooterContent struct {
 	Type            string
 	Text            string
 	LogoSrc         string
+	LogoAlt         string
+	BrandText       string // Optional wordmark next to the logo
 	Address         string
 	Tags            []string
 	Links           []Link
@@ -38,6 +43,8 @@ type SocialLink struct {
 type Footer struct {
 	Columns     []FooterColumn
 	SocialLinks []SocialLink
+	Copyright   string // Bottom bar text, e.g. "© {year} Company"; {year} becomes the current year
+	BottomLinks []Link // Bottom bar links, e.g. Privacy and Terms
 	CSSClass    string
 }
 
@@ -79,25 +86,46 @@ func (f *Footer) RenderHTML() string {
                 <ul class="flex">
 %s                </ul>
             </div>
-        </div>
+%s        </div>
     </footer>
 `
 
-	return Fmt(tpl, classEsc, columnsHTML, socialHTML)
+	return Fmt(tpl, classEsc, columnsHTML, socialHTML, f.renderBottomBar())
+}
+
+// renderBottomBar renders the copyright line and bottom links, or "" when
+// neither is set.
+func (f *Footer) renderBottomBar() string {
+	if f.Copyright == "" && len(f.BottomLinks) == 0 {
+		return ""
+	}
+
+	items := ""
+	if f.Copyright != "" {
+		year := Convert(time.Now().Year()).String()
+		copyright := Convert(f.Copyright).Replace("{year}", year).EscapeHTML()
+		items += Fmt("                <span class=\"footer-copyright\">%s</span>\n", copyright)
+	}
+	for _, link := range f.BottomLinks {
+		items += Fmt("                <a href=\"%s\">%s</a>\n", Convert(link.Href).EscapeAttr(), Convert(link.Label).EscapeHTML())
+	}
+	return Fmt(`            <div class="footer-bottom text-white text-sm">
+%s            </div>
+`, items)
 }
 
 func (f *Footer) renderColumnContent(content FooterContent) string {
 	switch content.Type {
 	case "about":
-		logoEsc := Convert(content.LogoSrc).EscapeAttr()
+		logoHTML := footerBrand(content).RenderHTML()
 		textEsc := Convert(content.Text).EscapeHTML()
 		addressEsc := Convert(content.Address).EscapeHTML()
 		return Fmt(`                <div class="icon">
-                    <img src="%s">
+                    %s
                 </div>
                 <p class="text text-md">%s</p>
                 <address>%s</address>
-`, logoEsc, textEsc, addressEsc)
+`, logoHTML, textEsc, addressEsc)
 
 	case "tags":
 		tagsHTML := ""
@@ -137,3 +165,20 @@ func (f *Footer) renderColumnContent(content FooterContent) string {
 		return ""
 	}
 }
+
+// footerBrand returns the logo of an "about" column.
+func footerBrand(content FooterContent) *brand.Brand {
+	return &brand.Brand{ImageSrc: content.LogoSrc, Alt: content.LogoAlt, Text: content.BrandText}
+}
+
+// ChildComponents returns the brands of the "about" columns so their CSS is
+// collected with the footer.
+func (f *Footer) ChildComponents() []any {
+	var children []any
+	for _, col := range f.Columns {
+		if col.Content.Type == "about" {
+			children = append(children, footerBrand(col.Content))
+		}
+	}
+	return children
+}
@@ -0,0 +1,16 @@
+//go:build !wasm
+// +build !wasm
+
+package grid
+
+import (
+	_ "embed"
+)
+
+//go:embed style.css
+var styleCss string
+
+// RenderCSS returns the CSS for the grid.
+func (g *Grid) RenderCSS() string {
+	return styleCss
+}
@@ -0,0 +1,62 @@
+package grid
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// HTMLRenderer is implemented by any component that can be placed in a grid.
+type HTMLRenderer interface {
+	RenderHTML() string
+}
+
+// Grid implements HTMLRenderer and CSSRenderer interfaces.
+// It lays out arbitrary child components in a configurable CSS grid.
+type Grid struct {
+	Columns  int    // Fixed number of columns on desktop (0 = auto-fit)
+	Gap      string // CSS gap between items, e.g. "2rem" (default 1.5rem)
+	Children []HTMLRenderer
+	CSSClass string
+}
+
+// RenderHTML generates the HTML for the grid and its children.
+func (g *Grid) RenderHTML() string {
+	class := "grid-layout"
+	style := ""
+	if g.Columns > 0 {
+		class += " grid-fixed"
+		style += Fmt("--grid-columns: %d;", g.Columns)
+	}
+	if g.Gap != "" {
+		style += Fmt(" --grid-gap: %s;", g.Gap)
+	}
+	if g.CSSClass != "" {
+		class += " " + g.CSSClass
+	}
+	classEsc := Convert(class).EscapeAttr()
+
+	styleAttr := ""
+	if style != "" {
+		styleAttr = Fmt(" style=\"%s\"", Convert(style).TrimSpace().EscapeAttr())
+	}
+
+	childrenHTML := ""
+	for _, child := range g.Children {
+		childrenHTML += child.RenderHTML()
+	}
+
+	tpl := `<div class="%s"%s>
+%s</div>
+`
+
+	return Fmt(tpl, classEsc, styleAttr, childrenHTML)
+}
+
+// ChildComponents returns the grid children so their CSS/JS is collected
+// when the grid is added to a section.
+func (g *Grid) ChildComponents() []any {
+	children := make([]any, len(g.Children))
+	for i, child := range g.Children {
+		children[i] = child
+	}
+	return children
+}
@@ -14,3 +14,12 @@ var styleCss string
 func (h *Hero) RenderCSS() string {
 return styleCss
 }
+
+//go:embed script.js
+var scriptJs string
+
+// RenderJS returns the JavaScript for the hero background slides and
+// headline variants.
+func (h *Hero) RenderJS() string {
+return scriptJs
+}
@@ -11,7 +11,7 @@ type Button struct {
 	CSSClass string // e.g., "btn-white", "btn-light-blue"
 }
 
-// Hero implements HTMLRenderer, CSSRenderer interfaces.
+// Hero implements HTMLRenderer, CSSRenderer and JSRenderer interfaces.
 // It provides a hero/header section with title, description, image, and call-to-action buttons.
 type Hero struct {
 	Title       string   // Main title
@@ -22,7 +22,24 @@ type Hero struct {
 	ImageAlt    string   // Hero image alt text
 	Buttons     []Button // Call-to-action buttons
 	BgColor     string   // CSS class for background color
-	CSSClass    string
+
+	// Optional background image. When set, the header uses it as
+	// background-image with a semi-transparent primary-color overlay.
+	BgImageSrc     string
+	OverlayOpacity float64 // Overlay opacity from 0 to 1 (default 0.5)
+
+	// Optional background slides, crossfaded in turn behind the fixed text
+	// and replacing BgImageSrc. The first is preloaded, the rest are loaded
+	// by the script just before they are shown.
+	BgImages []string
+
+	// Optional candidate headlines for an A/B test, replacing Title. With two
+	// or more, the script picks one per visitor, remembers it in localStorage
+	// and reports its index in the header's data-variant attribute. A single
+	// variant renders as a plain title.
+	Variants []string
+
+	CSSClass string
 }
 
 // RenderHTML generates the HTML for the hero section.
@@ -32,13 +49,41 @@ func (h *Hero) RenderHTML() string {
 	if h.BgColor != "" {
 		bgClass = "header " + h.BgColor
 	}
+	if len(h.BgImages) > 0 {
+		bgClass += " header-bg-image header-has-slides"
+	} else if h.BgImageSrc != "" {
+		bgClass += " header-bg-image"
+	}
 	if h.CSSClass != "" {
 		bgClass += " " + h.CSSClass
 	}
 	bgClassEsc := Convert(bgClass).EscapeAttr()
 
+	// Build background image style
+	styleAttr := ""
+	if len(h.BgImages) > 0 || h.BgImageSrc != "" {
+		opacity := h.OverlayOpacity
+		if opacity <= 0 || opacity > 1 {
+			opacity = 0.5
+		}
+		style := Fmt("--hero-overlay: %v;", opacity)
+		if len(h.BgImages) == 0 {
+			style = Fmt("background-image: %s; %s", cssURL(h.BgImageSrc), style)
+		}
+		styleAttr = Fmt(" style=\"%s\"", Convert(style).EscapeAttr())
+	}
+
 	// Build title with optional span
-	titleHTML := Convert(h.Title).EscapeHTML()
+	title := h.Title
+	if len(h.Variants) == 1 {
+		title = h.Variants[0]
+	}
+	titleHTML := Convert(title).EscapeHTML()
+	variantAttr := ""
+	if len(h.Variants) > 1 {
+		titleHTML = h.renderVariants()
+		variantAttr = " data-variant=\"0\""
+	}
 	if h.TitleSpan != "" {
 		spanEsc := Convert(h.TitleSpan).EscapeHTML()
 		titleHTML += Fmt("<br> <span>%s</span>", spanEsc)
@@ -62,8 +107,8 @@ func (h *Hero) RenderHTML() string {
 	imgSrcEsc := Convert(h.ImageSrc).EscapeAttr()
 	imgAltEsc := Convert(h.ImageAlt).EscapeAttr()
 
-	tpl := `    <header class="%s">
-        <div class="header-inner text-white text-center">
+	tpl := `%s    <header class="%s"%s%s>
+%s        <div class="header-inner text-white text-center">
             <div class="container grid">
                 <div class="header-inner-left">
                     <h1>%s</h1>
@@ -80,5 +125,48 @@ func (h *Hero) RenderHTML() string {
     </header>
 `
 
-	return Fmt(tpl, bgClassEsc, titleHTML, leadEsc, descEsc, buttonsHTML, imgSrcEsc, imgAltEsc)
+	preloadHTML, slidesHTML := h.renderSlides()
+
+	return Fmt(tpl, preloadHTML, bgClassEsc, styleAttr, variantAttr, slidesHTML, titleHTML, leadEsc, descEsc, buttonsHTML, imgSrcEsc, imgAltEsc)
+}
+
+// renderVariants returns the candidate headlines, all but the first hidden
+// until the script picks one; crawlers and visitors without JS see the first.
+func (h *Hero) renderVariants() string {
+	variants := ""
+	for i, v := range h.Variants {
+		hidden := ""
+		if i > 0 {
+			hidden = " hidden"
+		}
+		variants += Fmt("<span class=\"header-variant\" data-variant=\"%d\"%s>%s</span>", i, hidden, Convert(v).EscapeHTML())
+	}
+	return variants
+}
+
+// renderSlides returns the preload link of the first background slide and
+// the slide layers, or two empty strings without BgImages.
+func (h *Hero) renderSlides() (preload, slides string) {
+	if len(h.BgImages) == 0 {
+		return "", ""
+	}
+	preload = Fmt("    <link rel=\"preload\" as=\"image\" href=\"%s\">\n", Convert(h.BgImages[0]).EscapeAttr())
+
+	for i, src := range h.BgImages {
+		bg := Convert(cssURL(src)).EscapeAttr()
+		if i == 0 {
+			slides += Fmt("            <div class=\"header-slide active\" style=\"background-image: %s;\"></div>\n", bg)
+		} else {
+			slides += Fmt("            <div class=\"header-slide\" data-bg=\"%s\"></div>\n", bg)
+		}
+	}
+	slides = Fmt("        <div class=\"header-slides\" aria-hidden=\"true\">\n%s        </div>\n", slides)
+	return preload, slides
+}
+
+// cssURL returns src as a quoted CSS url() value, escaping characters that
+// could terminate the string. The result still needs attribute escaping.
+func cssURL(src string) string {
+	src = Convert(src).Replace("\\", "\\\\").Replace("\"", "\\\"").Replace("\n", "").Replace("\r", "").String()
+	return "url(\"" + src + "\")"
 }
@@ -0,0 +1,52 @@
+// Component: Hero
+(function() {
+  // Headline A/B test: pick a variant once per visitor and page, then keep it
+  document.querySelectorAll('.header[data-variant]').forEach(function(header, index) {
+    const variants = header.querySelectorAll('.header-variant');
+    if (variants.length < 2) return;
+
+    const key = 'hero-variant:' + location.pathname + ':' + index;
+    let choice = -1;
+    try {
+      choice = parseInt(localStorage.getItem(key), 10);
+    } catch (e) {}
+    if (!(choice >= 0 && choice < variants.length)) {
+      choice = Math.floor(Math.random() * variants.length);
+      try {
+        localStorage.setItem(key, String(choice));
+      } catch (e) {}
+    }
+
+    variants.forEach(function(variant, i) {
+      variant.hidden = i !== choice;
+    });
+    header.dataset.variant = String(choice);
+  });
+
+  const reduceMotion = window.matchMedia('(prefers-reduced-motion: reduce)').matches;
+
+  document.querySelectorAll('.header-slides').forEach(function(container) {
+    const slides = container.querySelectorAll('.header-slide');
+    if (slides.length < 2 || reduceMotion) return;
+
+    // Lazy-load a slide's image by moving data-bg into its style
+    function load(slide) {
+      if (slide.dataset.bg) {
+        slide.style.backgroundImage = slide.dataset.bg;
+        delete slide.dataset.bg;
+      }
+    }
+
+    let current = 0;
+    load(slides[1]);
+
+    setInterval(function() {
+      slides[current].classList.remove('active');
+      current = (current + 1) % slides.length;
+      load(slides[current]);
+      slides[current].classList.add('active');
+      // Fetch the following slide ahead of its turn
+      load(slides[(current + 1) % slides.length]);
+    }, 5000);
+  });
+})();
@@ -0,0 +1,16 @@
+//go:build !wasm
+// +build !wasm
+
+package split
+
+import (
+	_ "embed"
+)
+
+//go:embed style.css
+var styleCss string
+
+// RenderCSS returns the CSS for the split block.
+func (s *Split) RenderCSS() string {
+	return styleCss
+}
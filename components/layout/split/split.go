@@ -0,0 +1,75 @@
+package split
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// Button represents a call-to-action button in the split block
+type Button struct {
+	Label    string
+	Href     string
+	CSSClass string // e.g., "btn-blue", "btn-white"
+}
+
+// Split implements HTMLRenderer and CSSRenderer interfaces.
+// It provides a responsive two-column feature block with an image on one side
+// and text on the other. Columns stack on mobile.
+type Split struct {
+	ImageSrc string
+	ImageAlt string
+	Title    string
+	Body     string
+	Reverse  bool // Place the image on the right instead of the left
+	Buttons  []Button
+	Reveal   bool // Fade in on scroll with gosite.Config.ScrollReveal
+	CSSClass string
+}
+
+// RenderHTML generates the HTML for the split block.
+func (s *Split) RenderHTML() string {
+	class := "split"
+	if s.Reverse {
+		class += " split-reverse"
+	}
+	if s.CSSClass != "" {
+		class += " " + s.CSSClass
+	}
+	classEsc := Convert(class).EscapeAttr()
+
+	imgSrcEsc := Convert(s.ImageSrc).EscapeAttr()
+	imgAltEsc := Convert(s.ImageAlt).EscapeAttr()
+	titleEsc := Convert(s.Title).EscapeHTML()
+	bodyEsc := Convert(s.Body).EscapeHTML()
+
+	buttonsHTML := ""
+	if len(s.Buttons) > 0 {
+		for _, btn := range s.Buttons {
+			labelEsc := Convert(btn.Label).EscapeHTML()
+			hrefEsc := Convert(btn.Href).EscapeAttr()
+			btnClassEsc := Convert("btn " + btn.CSSClass).EscapeAttr()
+			buttonsHTML += Fmt(`            <a href="%s" class="%s">%s</a>
+`, hrefEsc, btnClassEsc, labelEsc)
+		}
+		buttonsHTML = Fmt(`        <div class="btn-group">
+%s        </div>
+`, buttonsHTML)
+	}
+
+	reveal := ""
+	if s.Reveal {
+		reveal = " data-reveal"
+	}
+
+	tpl := `<div class="%s"%s>
+    <div class="split-media">
+        <img src="%s" alt="%s">
+    </div>
+    <div class="split-content">
+        <h2>%s</h2>
+        <p class="text text-md">%s</p>
+%s    </div>
+</div>
+`
+
+	return Fmt(tpl, classEsc, reveal, imgSrcEsc, imgAltEsc, titleEsc, bodyEsc, buttonsHTML)
+}
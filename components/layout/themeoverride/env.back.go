@@ -0,0 +1,16 @@
+//go:build !wasm
+// +build !wasm
+
+package themeoverride
+
+import (
+	_ "embed"
+)
+
+//go:embed style.css
+var styleCss string
+
+// RenderCSS returns the CSS for the theme override wrapper.
+func (t *ThemeOverride) RenderCSS() string {
+	return styleCss
+}
@@ -0,0 +1,105 @@
+package themeoverride
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// HTMLRenderer is implemented by any component that can be recolored.
+type HTMLRenderer interface {
+	RenderHTML() string
+}
+
+// ThemeOverride implements HTMLRenderer and CSSRenderer interfaces.
+// It wraps child components in an element that redefines theme CSS variables,
+// e.g. a single highlighted pricing card. The variables only cascade to the
+// children; the wrapper uses display: contents, so the layout is unchanged.
+type ThemeOverride struct {
+	// Variables to redefine. Short names get the "--color-" prefix
+	// ("primary" sets --color-primary); names starting with "--" are used as
+	// is. Names with characters other than a-z, 0-9 and "-", and values
+	// containing ";", "{" or "}", are skipped.
+	Colors   map[string]string
+	Children []HTMLRenderer
+	CSSClass string
+}
+
+// RenderHTML generates the HTML for the scoped wrapper and its children.
+func (t *ThemeOverride) RenderHTML() string {
+	class := "theme-override"
+	if t.CSSClass != "" {
+		class += " " + t.CSSClass
+	}
+	classEsc := Convert(class).EscapeAttr()
+
+	styleAttr := ""
+	if style := t.style(); style != "" {
+		styleAttr = Fmt(" style=\"%s\"", Convert(style).EscapeAttr())
+	}
+
+	childrenHTML := ""
+	for _, child := range t.Children {
+		childrenHTML += child.RenderHTML()
+	}
+
+	tpl := `<div class="%s"%s>
+%s</div>
+`
+
+	return Fmt(tpl, classEsc, styleAttr, childrenHTML)
+}
+
+// style returns the variable declarations sorted by name so the output is
+// reproducible.
+func (t *ThemeOverride) style() string {
+	names := make([]string, 0, len(t.Colors))
+	for name, value := range t.Colors {
+		if !validName(name) || Contains(value, ";") || Contains(value, "{") || Contains(value, "}") {
+			continue
+		}
+		names = append(names, name)
+	}
+	// Insertion sort: the maps are a handful of entries
+	for i := 1; i < len(names); i++ {
+		for j := i; j > 0 && names[j] < names[j-1]; j-- {
+			names[j], names[j-1] = names[j-1], names[j]
+		}
+	}
+
+	style := ""
+	for _, name := range names {
+		prop := name
+		if !HasPrefix(prop, "--") {
+			prop = "--color-" + prop
+		}
+		if style != "" {
+			style += " "
+		}
+		style += Fmt("%s: %s;", prop, Convert(t.Colors[name]).TrimSpace().String())
+	}
+	return style
+}
+
+// validName reports whether name is a non-empty run of a-z, 0-9 and "-", so it
+// cannot add declarations to the style attribute.
+func validName(name string) bool {
+	if name == "" || name == "--" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// ChildComponents returns the children so their CSS/JS is collected when the
+// wrapper is added to a section.
+func (t *ThemeOverride) ChildComponents() []any {
+	children := make([]any, len(t.Children))
+	for i, child := range t.Children {
+		children[i] = child
+	}
+	return children
+}
@@ -0,0 +1,47 @@
+package backtotop
+
+import (
+	"github.com/cdvelop/gosite/components/i18n"
+	. "github.com/cdvelop/tinystring"
+)
+
+// BackToTop implements HTMLRenderer, CSSRenderer, and JSRenderer interfaces.
+// It renders a floating button shown once the page is scrolled past Threshold.
+type BackToTop struct {
+	Threshold int    // Scroll offset in px before the button appears (default 300)
+	Icon      string // Visible button content (default "↑")
+	Label     string // Accessible name (default: translated "Back to top")
+	CSSClass  string
+}
+
+// RenderHTML generates the HTML for the back-to-top button.
+func (b *BackToTop) RenderHTML() string {
+	class := "back-to-top"
+	if b.CSSClass != "" {
+		class += " " + b.CSSClass
+	}
+
+	threshold := b.Threshold
+	if threshold <= 0 {
+		threshold = 300
+	}
+	icon := b.Icon
+	if icon == "" {
+		icon = "↑"
+	}
+	label := b.Label
+	if label == "" {
+		label = Translate(i18n.D.BackToTop).String()
+	}
+
+	classEsc := Convert(class).EscapeAttr()
+	labelEsc := Convert(label).EscapeAttr()
+	iconEsc := Convert(icon).EscapeHTML()
+
+	tpl := `<button type="button" class="%s" aria-label="%s" data-threshold="%d" hidden>
+    <span aria-hidden="true">%s</span>
+</button>
+`
+
+	return Fmt(tpl, classEsc, labelEsc, threshold, iconEsc)
+}
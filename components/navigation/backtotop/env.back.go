@@ -0,0 +1,24 @@
+//go:build !wasm
+// +build !wasm
+
+package backtotop
+
+import (
+	_ "embed"
+)
+
+//go:embed style.css
+var styleCss string
+
+// RenderCSS returns the CSS for the back-to-top button.
+func (b *BackToTop) RenderCSS() string {
+	return styleCss
+}
+
+//go:embed script.js
+var scriptJs string
+
+// RenderJS returns the JavaScript for the back-to-top button.
+func (b *BackToTop) RenderJS() string {
+	return scriptJs
+}
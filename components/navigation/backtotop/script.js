@@ -0,0 +1,35 @@
+// Component: BackToTop
+(function() {
+  const buttons = document.querySelectorAll('.back-to-top');
+  if (!buttons.length) return;
+
+  const reduceMotion = window.matchMedia('(prefers-reduced-motion: reduce)');
+
+  buttons.forEach(function(button) {
+    const threshold = parseInt(button.dataset.threshold, 10) || 300;
+    // Keep the button out of the tab order until it is shown
+    button.hidden = false;
+    button.tabIndex = -1;
+
+    let ticking = false;
+    function update() {
+      const visible = window.scrollY > threshold;
+      button.classList.toggle('visible', visible);
+      button.tabIndex = visible ? 0 : -1;
+      ticking = false;
+    }
+
+    window.addEventListener('scroll', function() {
+      if (!ticking) {
+        ticking = true;
+        window.requestAnimationFrame(update);
+      }
+    }, { passive: true });
+
+    button.addEventListener('click', function() {
+      window.scrollTo({ top: 0, behavior: reduceMotion.matches ? 'auto' : 'smooth' });
+    });
+
+    update();
+  });
+})();
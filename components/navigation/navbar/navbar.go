@@ -1,6 +1,8 @@
 package navbar
 
 import (
+	"github.com/cdvelop/gosite/components/content/brand"
+	"github.com/cdvelop/gosite/components/i18n"
 	. "github.com/cdvelop/tinystring"
 )
 
@@ -16,6 +18,7 @@ type Navbar struct {
 	LogoSrc    string
 	LogoAlt    string
 	LogoHref   string
+	BrandText  string // Optional wordmark next to the logo
 	NavItems   []NavItem
 	ShowSearch bool
 	BgColor    string // CSS class for background color
@@ -34,14 +37,7 @@ func (n *Navbar) RenderHTML() string {
 	}
 	bgClassEsc := Convert(bgClass).EscapeAttr()
 
-	// Build logo
-	logoSrcEsc := Convert(n.LogoSrc).EscapeAttr()
-	logoAltEsc := Convert(n.LogoAlt).EscapeAttr()
-	logoHrefEsc := Convert(n.LogoHref).EscapeAttr()
-
-	logoHTML := Fmt(`        <a href="%s" class="navbar-brand">
-            <img src="%s" alt="%s">
-        </a>`, logoHrefEsc, logoSrcEsc, logoAltEsc)
+	logoHTML := "        " + n.brand().RenderHTML()
 
 	// Build nav items
 	navItemsHTML := ""
@@ -57,29 +53,30 @@ func (n *Navbar) RenderHTML() string {
 	// Build search bar
 	searchHTML := ""
 	if n.ShowSearch {
-		searchHTML = `                    <div class="search-bar">
+		searchPlaceholder := Translate(i18n.D.SearchHere).EscapeAttr()
+		searchHTML = Fmt(`                    <div class="search-bar">
                         <form>
                             <div class="search-bar-box flex">
                                 <span class="search-icon flex">
-                                    <img src="images/search-icon-dark.png">
+                                    %s
                                 </span>
-                                <input type="search" class="search-control" placeholder="Search here">
+                                <input type="search" class="search-control" placeholder="%s">
                             </div>
                         </form>
                     </div>
-`
+`, svgIcon(searchIconPath), searchPlaceholder)
 	}
 
 	tpl := `    <nav class="%s">
         <div class="container flex">
 %s
-        <button type="button" class="navbar-show-btn">
-            <img src="images/ham-menu-icon.png">
+        <button type="button" class="navbar-show-btn" aria-label="%s">
+            %s
         </button>
 
         <div class="navbar-collapse bg-white">
-            <button type="button" class="navbar-hide-btn">
-                <img src="images/close-icon.png">
+            <button type="button" class="navbar-hide-btn" aria-label="%s">
+                %s
             </button>
             <ul class="navbar-nav">
 %s            </ul>
@@ -88,5 +85,30 @@ func (n *Navbar) RenderHTML() string {
     </nav>
 `
 
-	return Fmt(tpl, bgClassEsc, logoHTML, navItemsHTML, searchHTML)
+	openLabel := Translate(i18n.D.OpenMenu).EscapeAttr()
+	closeLabel := Translate(i18n.D.CloseMenu).EscapeAttr()
+
+	return Fmt(tpl, bgClassEsc, logoHTML, openLabel, svgIcon(menuIconPath), closeLabel, svgIcon(closeIconPath), navItemsHTML, searchHTML)
+}
+
+// Icon paths (24x24 viewBox) rendered inline, so no image files are needed.
+const (
+	menuIconPath   = "M3 6h18v2H3zm0 5h18v2H3zm0 5h18v2H3z"
+	closeIconPath  = "M19 6.41 17.59 5 12 10.59 6.41 5 5 6.41 10.59 12 5 17.59 6.41 19 12 13.41 17.59 19 19 17.59 13.41 12z"
+	searchIconPath = "M15.5 14h-.79l-.28-.27A6.47 6.47 0 0 0 16 9.5 6.5 6.5 0 1 0 9.5 16c1.61 0 3.09-.59 4.23-1.57l.27.28v.79l5 4.99L20.49 19zm-6 0C7.01 14 5 11.99 5 9.5S7.01 5 9.5 5 14 7.01 14 9.5 11.99 14 9.5 14"
+)
+
+// svgIcon returns a decorative inline SVG drawing path in the current color.
+func svgIcon(path string) string {
+	return Fmt(`<svg viewBox="0 0 24 24" width="24" height="24" fill="currentColor" aria-hidden="true" focusable="false"><path d="%s"/></svg>`, path)
+}
+
+// brand returns the logo of the navbar.
+func (n *Navbar) brand() *brand.Brand {
+	return &brand.Brand{ImageSrc: n.LogoSrc, Alt: n.LogoAlt, Text: n.BrandText, Href: n.LogoHref, CSSClass: "navbar-brand"}
+}
+
+// ChildComponents returns the brand so its CSS is collected with the navbar.
+func (n *Navbar) ChildComponents() []any {
+	return []any{n.brand()}
 }
@@ -0,0 +1,24 @@
+//go:build !wasm
+// +build !wasm
+
+package progressbar
+
+import (
+	_ "embed"
+)
+
+//go:embed style.css
+var styleCss string
+
+// RenderCSS returns the CSS for the progress bar.
+func (p *ProgressBar) RenderCSS() string {
+	return styleCss
+}
+
+//go:embed script.js
+var scriptJs string
+
+// RenderJS returns the JavaScript for the progress bar.
+func (p *ProgressBar) RenderJS() string {
+	return scriptJs
+}
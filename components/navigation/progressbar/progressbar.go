@@ -0,0 +1,43 @@
+package progressbar
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// ProgressBar implements HTMLRenderer, CSSRenderer, and JSRenderer interfaces.
+// It renders a fixed bar at the top of the viewport showing how far the page
+// has been scrolled.
+type ProgressBar struct {
+	Height   string // CSS length, e.g. "4px" (default 4px)
+	Color    string // CSS color (default: theme primary color)
+	CSSClass string
+}
+
+// RenderHTML generates the HTML for the reading progress bar.
+func (p *ProgressBar) RenderHTML() string {
+	class := "progress-bar"
+	if p.CSSClass != "" {
+		class += " " + p.CSSClass
+	}
+
+	style := ""
+	if p.Height != "" {
+		style += "--progress-height: " + p.Height + ";"
+	}
+	if p.Color != "" {
+		style += "--progress-color: " + p.Color + ";"
+	}
+	styleAttr := ""
+	if style != "" {
+		styleAttr = Fmt(" style=\"%s\"", Convert(style).EscapeAttr())
+	}
+
+	classEsc := Convert(class).EscapeAttr()
+
+	tpl := `<div class="%s"%s aria-hidden="true">
+    <div class="progress-bar-fill"></div>
+</div>
+`
+
+	return Fmt(tpl, classEsc, styleAttr)
+}
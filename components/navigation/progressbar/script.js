@@ -0,0 +1,28 @@
+// Component: ProgressBar
+(function() {
+  const fills = document.querySelectorAll('.progress-bar-fill');
+  if (!fills.length) return;
+
+  let ticking = false;
+  function update() {
+    const doc = document.documentElement;
+    const scrollable = doc.scrollHeight - window.innerHeight;
+    const progress = scrollable > 0 ? Math.min(window.scrollY / scrollable, 1) : 0;
+    fills.forEach(function(fill) {
+      fill.style.transform = 'scaleX(' + progress + ')';
+    });
+    ticking = false;
+  }
+
+  // Throttle to one update per animation frame
+  function onScroll() {
+    if (!ticking) {
+      ticking = true;
+      window.requestAnimationFrame(update);
+    }
+  }
+
+  window.addEventListener('scroll', onScroll, { passive: true });
+  window.addEventListener('resize', onScroll);
+  update();
+})();
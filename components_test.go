@@ -0,0 +1,971 @@
+package gosite_test
+
+import (
+	"encoding/json"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cdvelop/gosite"
+	"github.com/cdvelop/gosite/components/carousel"
+	"github.com/cdvelop/gosite/components/content/avatar"
+	"github.com/cdvelop/gosite/components/content/brand"
+	"github.com/cdvelop/gosite/components/content/codeblock"
+	"github.com/cdvelop/gosite/components/content/countdown"
+	"github.com/cdvelop/gosite/components/content/details"
+	"github.com/cdvelop/gosite/components/content/doctorcard"
+	"github.com/cdvelop/gosite/components/content/faq"
+	"github.com/cdvelop/gosite/components/content/list"
+	"github.com/cdvelop/gosite/components/content/mapembed"
+	"github.com/cdvelop/gosite/components/content/postcard"
+	"github.com/cdvelop/gosite/components/content/quote"
+	"github.com/cdvelop/gosite/components/content/sectionhead"
+	"github.com/cdvelop/gosite/components/content/servicecard"
+	"github.com/cdvelop/gosite/components/content/share"
+	"github.com/cdvelop/gosite/components/forms/contactform"
+	"github.com/cdvelop/gosite/components/forms/form"
+	"github.com/cdvelop/gosite/components/forms/formPlatform"
+	"github.com/cdvelop/gosite/components/layout/banner"
+	"github.com/cdvelop/gosite/components/layout/cta"
+	"github.com/cdvelop/gosite/components/layout/divider"
+	"github.com/cdvelop/gosite/components/layout/footer"
+	"github.com/cdvelop/gosite/components/layout/hero"
+	"github.com/cdvelop/gosite/components/layout/split"
+	"github.com/cdvelop/gosite/components/layout/themeoverride"
+	"github.com/cdvelop/gosite/components/navigation/backtotop"
+	"github.com/cdvelop/gosite/components/navigation/navbar"
+	"github.com/cdvelop/gosite/components/navigation/progressbar"
+	"github.com/cdvelop/tinystring"
+)
+
+func TestFormEscapesAttributes(t *testing.T) {
+	f := &form.Form{Config: form.Config{
+		Action: `/send" onsubmit="x`,
+		Method: `POST" onload="x`,
+		Fields: []form.Field{
+			{Type: `text" onfocus="x`, Name: `n" a="b`, Placeholder: `<script>`},
+		},
+	}}
+	html := f.RenderHTML()
+
+	for _, raw := range []string{`" onsubmit=`, `" onload=`, `" onfocus=`, `" a=`, `<script>`} {
+		if strings.Contains(html, raw) {
+			t.Errorf("unescaped value %q in form HTML:\n%s", raw, html)
+		}
+	}
+	if !strings.Contains(html, `method="POST&quot; onload=&quot;x"`) {
+		t.Errorf("method not escaped with EscapeAttr:\n%s", html)
+	}
+}
+
+func TestFormFieldValidationAttributes(t *testing.T) {
+	f := &form.Form{Config: form.Config{Fields: []form.Field{
+		{Type: "tel", Name: "phone", Pattern: `[0-9]{9}`},
+		{Type: "number", Name: "age", Min: "18", Max: "99"},
+		{Type: "textarea", Name: "msg", MinLength: 10, MaxLength: 500},
+		{Type: "bogus", Name: "other"},
+	}}}
+	html := f.RenderHTML()
+
+	for _, want := range []string{
+		`<input type="tel" name="phone" placeholder="" inputmode="tel" pattern="[0-9]{9}">`,
+		`<input type="number" name="age" placeholder="" min="18" max="99">`,
+		`<textarea name="msg" placeholder="" minlength="10" maxlength="500"></textarea>`,
+		`<input type="text" name="other" placeholder="">`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("form HTML missing %s\ngot:\n%s", want, html)
+		}
+	}
+}
+
+func TestFormSelectField(t *testing.T) {
+	f := &form.Form{Config: form.Config{Fields: []form.Field{{
+		Type:        "select",
+		Name:        "department",
+		Placeholder: "Choose a department",
+		Required:    true,
+		Options: []form.Option{
+			{Value: "sales", Label: "Sales"},
+			{Value: `x" y`, Label: "<R&D>"},
+		},
+	}}}}
+	html := f.RenderHTML()
+
+	for _, want := range []string{
+		`<select name="department" required>`,
+		`<option value="">Choose a department</option>`,
+		`<option value="sales">Sales</option>`,
+		`<option value="x&quot; y">&lt;R&amp;D&gt;</option>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("select HTML missing %s\ngot:\n%s", want, html)
+		}
+	}
+}
+
+func TestFormCSRFToken(t *testing.T) {
+	plain := (&form.Form{}).RenderHTML()
+	if strings.Contains(plain, "csrf_token") {
+		t.Errorf("csrf field rendered without CSRFTokenFunc:\n%s", plain)
+	}
+
+	f := &form.Form{Config: form.Config{CSRFTokenFunc: func() string { return `a"b` }}}
+	if html := f.RenderHTML(); !strings.Contains(html, `<input type="hidden" name="csrf_token" value="a&quot;b">`) {
+		t.Errorf("missing escaped csrf field:\n%s", html)
+	}
+
+	f = &form.Form{Config: form.Config{CSRFTokenFunc: form.CSRFTemplate}}
+	if html := f.RenderHTML(); !strings.Contains(html, `value="{{.CSRFToken}}"`) {
+		t.Errorf("missing csrf placeholder:\n%s", html)
+	}
+}
+
+func TestFormFromStruct(t *testing.T) {
+	type contact struct {
+		ID       int
+		Name     string `Input:"(placeholder=Your name,maxlength=50)"`
+		Email    string
+		TextArea string `Input:"textarea(!required)"`
+		Gender   string
+		Radio    string `Input:"(options=a:Alpha;b:Beta)"`
+		Internal string `Input:"-"`
+		secret   string
+	}
+
+	f, err := form.FromStruct(&contact{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := f.RenderHTML()
+
+	for _, want := range []string{
+		`<input type="hidden" name="id">`,
+		`placeholder="Your name" minlength="2" maxlength="50" required>`,
+		`<input type="email" name="email"`,
+		`<textarea name="text_area"`,
+		`<input type="radio" name="gender" value="f" required>`,
+		`<input type="radio" name="gender" value="m" required>`,
+		`<label><input type="radio" name="radio" value="a" required> Alpha</label>`,
+		`<label><input type="radio" name="radio" value="b" required> Beta</label>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("form HTML missing %s\ngot:\n%s", want, html)
+		}
+	}
+	if strings.Contains(html, "<select") || strings.Contains(html, `placeholder=""`) {
+		t.Errorf("radio rendered as select or empty placeholder emitted:\n%s", html)
+	}
+	if strings.Contains(html, `text_area" required`) || strings.Contains(html, "</textarea> required") {
+		t.Errorf("!required ignored:\n%s", html)
+	}
+	if strings.Contains(html, "internal") || strings.Contains(html, "secret") {
+		t.Errorf("skipped fields rendered:\n%s", html)
+	}
+
+	type unknown struct{ Nickname string }
+	if _, err := form.FromStruct(unknown{}); err == nil || !strings.Contains(err.Error(), "nickname") {
+		t.Errorf("expected dictionary error naming the field, got %v", err)
+	}
+}
+
+// fieldValidation returns the ExtraValidation of the formPlatform field name.
+func fieldValidation(t *testing.T, name string, params ...any) func(string) error {
+	t.Helper()
+	f, err := formPlatform.NewField(name, params...)
+	if err != nil {
+		t.Fatalf("NewField(%q): %v", name, err)
+	}
+	if f.ExtraValidation == nil {
+		t.Fatalf("field %q has no ExtraValidation", name)
+	}
+	return f.ExtraValidation
+}
+
+func TestFormPlatformEmail(t *testing.T) {
+	validate := fieldValidation(t, "email")
+	for _, tc := range []struct {
+		email string
+		valid bool
+	}{
+		{"mi.correo@mail.com", true},
+		{"a_b@sub.domain.cl", true},
+		{"user@example.com", false},
+		{"no-at-sign.com", false},
+		{"a@b@mail.com", false},
+		{"@mail.com", false},
+		{".a@mail.com", false},
+		{"a.@mail.com", false},
+		{"a..b@mail.com", false},
+		{"a@localhost", false},
+		{"a@.mail.com", false},
+		{"a@mail.com.", false},
+		{"a@mail..com", false},
+	} {
+		if err := validate(tc.email); (err == nil) != tc.valid {
+			t.Errorf("validate(%q) = %v, want valid %v", tc.email, err, tc.valid)
+		}
+	}
+}
+
+func TestFormPlatformIP(t *testing.T) {
+	validate := fieldValidation(t, "ip")
+	for _, tc := range []struct {
+		ip    string
+		valid bool
+	}{
+		{"192.168.0.8", true},
+		{"0.0.0.0", false},
+		{"192.168.0", false},
+		{"::", true},
+		{"::1", true},
+		{"fe80::1", true},
+		{"2001:db8:0:0:0:0:0:1", true},
+		{"2001:DB8::abcd", true},
+		{"::ffff:192.168.0.8", true},
+		{"1:2:3:4:5:6:192.168.0.8", true},
+		{"::ffff:256.1.1.1", false},
+		{"::192.168.0.8:1", false},
+		{":::", false},
+		{"1::2::3", false},
+		{"1:2:3:4:5:6:7:8:9", false},
+		{"1:2:3:4:5:6:7:8::", false},
+		{"1:2:3:4:5:6:7", false},
+		{"2001:db8::g", false},
+		{"12345::1", false},
+	} {
+		if err := validate(tc.ip); (err == nil) != tc.valid {
+			t.Errorf("validate(%q) = %v, want valid %v", tc.ip, err, tc.valid)
+		}
+	}
+}
+
+func TestFormPlatformRut(t *testing.T) {
+	for _, tc := range []struct {
+		in, formatted, unformatted string
+	}{
+		{"12345678-5", "12.345.678-5", "12345678-5"},
+		{"12.345.678-5", "12.345.678-5", "12345678-5"},
+		{"11 222 333 - 9", "11.222.333-9", "11222333-9"},
+		{"7654321-6", "7.654.321-6", "7654321-6"},
+		{"10.000.013-K", "10.000.013-k", "10000013-k"},
+		{"1000005-k", "1.000.005-k", "1000005-k"},
+		{"6-K", "6-k", "6-k"},
+	} {
+		formatted, err := formPlatform.FormatRut(tc.in)
+		if err != nil || formatted != tc.formatted {
+			t.Errorf("FormatRut(%q) = %q, %v; want %q", tc.in, formatted, err, tc.formatted)
+		}
+		unformatted, err := formPlatform.UnformatRut(tc.in)
+		if err != nil || unformatted != tc.unformatted {
+			t.Errorf("UnformatRut(%q) = %q, %v; want %q", tc.in, unformatted, err, tc.unformatted)
+		}
+		// Round trip: formatting and unformatting are inverse of each other.
+		if back, err := formPlatform.UnformatRut(formatted); err != nil || back != tc.unformatted {
+			t.Errorf("UnformatRut(FormatRut(%q)) = %q, %v", tc.in, back, err)
+		}
+		if again, err := formPlatform.FormatRut(unformatted); err != nil || again != tc.formatted {
+			t.Errorf("FormatRut(UnformatRut(%q)) = %q, %v", tc.in, again, err)
+		}
+	}
+
+	for _, bad := range []string{"12345678-4", "10000013-1", "12345678-kk", "12345678", "1234a678-5", "012345678-5", "-5", ""} {
+		if _, err := formPlatform.FormatRut(bad); err == nil {
+			t.Errorf("FormatRut(%q) accepted an invalid RUT", bad)
+		}
+		if _, err := formPlatform.UnformatRut(bad); err == nil {
+			t.Errorf("UnformatRut(%q) accepted an invalid RUT", bad)
+		}
+	}
+}
+
+func TestFormPlatformDate(t *testing.T) {
+	validate := fieldValidation(t, "date")
+	for _, tc := range []struct {
+		date  string
+		valid bool
+	}{
+		{"29-02-2024", true},  // divisible by 4
+		{"29-02-2023", false}, // common year
+		{"29-02-1900", false}, // divisible by 100 but not 400
+		{"29-02-2000", true},  // divisible by 400
+		{"28-02-2023", true},
+		{"31-01-2023", true},
+		{"31-03-2023", true},
+		{"31-04-2023", false},
+		{"31-06-2023", false},
+		{"31-09-2023", false},
+		{"31-11-2023", false},
+		{"30-11-2023", true},
+		{"31-12-2023", true},
+		{"00-01-2023", false},
+		{"01-13-2023", false},
+		{"01-00-2023", false},
+		{"01-01-0000", false},
+		{"1-1-2023", false},
+		{"2023-01-01", false},
+		{"aa-01-2023", false},
+	} {
+		if err := validate(tc.date); (err == nil) != tc.valid {
+			t.Errorf("validate(%q) = %v, want valid %v", tc.date, err, tc.valid)
+		}
+	}
+
+	bounded := fieldValidation(t, "date", "min=01-01-2020", "max=31-12-2020")
+	for date, valid := range map[string]bool{"01-01-2020": true, "31-12-2020": true, "31-12-2019": false, "01-01-2021": false} {
+		if err := bounded(date); (err == nil) != valid {
+			t.Errorf("bounded validate(%q) = %v, want valid %v", date, err, valid)
+		}
+	}
+	if err := fieldValidation(t, "birth_date")("01-01-2999"); err == nil {
+		t.Error("birth_date accepted a future date")
+	}
+}
+
+func TestFormPlatformInputAttributes(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		params  []any
+		want    []string
+		notWant []string
+	}{
+		{"phone", nil,
+			[]string{`<input type="tel"`, `pattern="[0-9]{7,11}"`, `minlength="7"`, `maxlength="11"`},
+			[]string{`min="`, `max="`}},
+		{"date", []any{"min=01-01-2020", "max=31-12-2020"},
+			[]string{`<input type="text"`, `pattern="[0-9]{2}-[0-9]{2}-[0-9]{4}"`, `placeholder="DD-MM-YYYY"`, `maxlength="10"`},
+			[]string{`type="date"`, `min="`, `max="`, `minlength`}},
+	} {
+		f, err := formPlatform.NewField(tc.name, tc.params...)
+		if err != nil {
+			t.Fatalf("NewField(%q): %v", tc.name, err)
+		}
+		html := f.RenderHTML()
+		for _, want := range tc.want {
+			if !strings.Contains(html, want) {
+				t.Errorf("%s: missing %s in %s", tc.name, want, html)
+			}
+		}
+		for _, bad := range tc.notWant {
+			if strings.Contains(html, bad) {
+				t.Errorf("%s: unexpected %s in %s", tc.name, bad, html)
+			}
+		}
+	}
+}
+
+func TestFormPlatformInternationalPhone(t *testing.T) {
+	chile := formPlatform.DialCode{Code: "56", Digits: 9}
+	validate := fieldValidation(t, "phone", chile)
+
+	for _, phone := range []string{"+56 912345678", "+56912345678", " +56 9123 45678"} {
+		if err := validate(phone); err != nil {
+			t.Errorf("validate(%q) = %v, want valid", phone, err)
+		}
+	}
+	wantLength := tinystring.Err(tinystring.D.Number, tinystring.D.Must, tinystring.D.Be, "9", tinystring.D.Digit).Error()
+	for _, phone := range []string{"+56 91234567", "+56 9123456789"} {
+		if err := validate(phone); err == nil || err.Error() != wantLength {
+			t.Errorf("validate(%q) = %v, want %q", phone, err, wantLength)
+		}
+	}
+	wantCode := tinystring.Err(tinystring.D.Number, tinystring.D.Must, tinystring.D.Begin, tinystring.D.With, "+56").Error()
+	for _, phone := range []string{"912345678", "56 912345678", "+54 912345678"} {
+		if err := validate(phone); err == nil || err.Error() != wantCode {
+			t.Errorf("validate(%q) = %v, want %q", phone, err, wantCode)
+		}
+	}
+	if err := validate("+56 9123a5678"); err == nil {
+		t.Error("validate accepted letters")
+	}
+
+	f, err := formPlatform.NewField("phone", chile)
+	if err != nil {
+		t.Fatalf("NewField: %v", err)
+	}
+	html := f.RenderHTML()
+	for _, want := range []string{`<input type="tel"`, `placeholder="ej: +56 999999999"`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("missing %s in %s", want, html)
+		}
+	}
+	if strings.Contains(html, "pattern=") {
+		t.Errorf("international phone keeps the national digits pattern: %s", html)
+	}
+}
+
+func TestFormPlatformPasswordStrength(t *testing.T) {
+	if f, err := formPlatform.NewField("password"); err != nil || f.ExtraValidation != nil {
+		t.Fatalf("password without PasswordStrength should stay lenient: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		strength formPlatform.PasswordStrength
+		below    string // one short of the threshold
+		at       string // exactly at the threshold
+	}{
+		{"upper", formPlatform.PasswordStrength{Upper: 2}, "Abcdef", "ABcdef"},
+		{"lower", formPlatform.PasswordStrength{Lower: 2}, "ABCDEf", "ABCDef"},
+		{"digits", formPlatform.PasswordStrength{Digits: 2}, "abcde1", "abcd12"},
+		{"special", formPlatform.PasswordStrength{Special: 2}, "abcd#e", "abc#%d"},
+	} {
+		validate := fieldValidation(t, "password", tc.strength)
+		if err := validate(tc.below); err == nil {
+			t.Errorf("%s: %q below the threshold was accepted", tc.name, tc.below)
+		}
+		if err := validate(tc.at); err != nil {
+			t.Errorf("%s: %q at the threshold was rejected: %v", tc.name, tc.at, err)
+		}
+	}
+
+	// Spaces and accented letters do not count as special characters.
+	validate := fieldValidation(t, "password", formPlatform.PasswordStrength{Special: 1})
+	if err := validate("añb cdé"); err == nil {
+		t.Error("space or accented letter counted as special")
+	}
+
+	all := fieldValidation(t, "password", formPlatform.PasswordStrength{Upper: 1, Lower: 1, Digits: 1, Special: 1})
+	if err := all("Abc1#"); err != nil {
+		t.Errorf("password meeting every rule rejected: %v", err)
+	}
+	if err := all("Abc1"); err == nil {
+		t.Error("password missing a special character accepted")
+	}
+}
+
+func TestComponentIDsForFrontendBinding(t *testing.T) {
+	if html := (&form.Form{Config: form.Config{ID: "contact"}}).RenderHTML(); !strings.HasPrefix(html, `<form id="contact" class="contact-form"`) {
+		t.Errorf("form id not rendered:\n%s", html)
+	}
+	if html := (&form.Form{}).RenderHTML(); strings.Contains(html, " id=") {
+		t.Errorf("form without ID renders an id:\n%s", html)
+	}
+	if html := (&carousel.Carousel{ID: "gallery"}).RenderHTML(); !strings.HasPrefix(html, `<div class="carousel" id="gallery">`) {
+		t.Errorf("carousel id not rendered:\n%s", html)
+	}
+}
+
+func TestBackToTop(t *testing.T) {
+	html := (&backtotop.BackToTop{}).RenderHTML()
+	if !strings.Contains(html, `<button type="button" class="back-to-top" aria-label="`) ||
+		!strings.Contains(html, `data-threshold="300"`) {
+		t.Errorf("unexpected default button:\n%s", html)
+	}
+
+	html = (&backtotop.BackToTop{Threshold: 800, Icon: "<up>", Label: `Top "now"`}).RenderHTML()
+	for _, want := range []string{`aria-label="Top &quot;now&quot;"`, `data-threshold="800"`, `&lt;up&gt;`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("button missing %s\ngot:\n%s", want, html)
+		}
+	}
+}
+
+func TestProgressBarStyleVars(t *testing.T) {
+	if html := (&progressbar.ProgressBar{}).RenderHTML(); strings.Contains(html, "style=") {
+		t.Errorf("default bar has inline style:\n%s", html)
+	}
+	html := (&progressbar.ProgressBar{Height: "6px", Color: `red" onload="x`}).RenderHTML()
+	if !strings.Contains(html, `style="--progress-height: 6px;--progress-color: red&quot; onload=&quot;x;"`) {
+		t.Errorf("style vars not set or not escaped:\n%s", html)
+	}
+}
+
+func TestAvatar(t *testing.T) {
+	for name, want := range map[string]string{
+		"ada lovelace byron": ">AL</span>",
+		"  élodie  ":         ">É</span>",
+		"":                   "></span>",
+	} {
+		if html := (&avatar.Avatar{Name: name}).RenderHTML(); !strings.Contains(html, want) {
+			t.Errorf("initials for %q: want %s, got:\n%s", name, want, html)
+		}
+	}
+
+	html := (&avatar.Avatar{Name: `Bo "B"`, ImageSrc: "bo.jpg", Size: 64}).RenderHTML()
+	want := `<img class="avatar" src="bo.jpg" alt="Bo &quot;B&quot;" width="64" height="64" style="--avatar-size: 64px;">`
+	if html != want {
+		t.Errorf("image avatar:\nwant %s\ngot  %s", want, html)
+	}
+}
+
+func TestBrand(t *testing.T) {
+	html := (&brand.Brand{ImageSrc: `logo.svg" onerror="x`, Alt: "Acme <Inc>", Text: "Acme & Co", Href: "/"}).RenderHTML()
+	want := `<a href="/" class="brand"><img class="brand-logo" src="logo.svg&quot; onerror=&quot;x" alt="Acme &lt;Inc&gt;"><span class="brand-text">Acme &amp; Co</span></a>`
+	if html != want {
+		t.Errorf("brand:\n got %s\nwant %s", html, want)
+	}
+	if html := (&brand.Brand{Text: "Acme"}).RenderHTML(); html != `<span class="brand"><span class="brand-text">Acme</span></span>` {
+		t.Errorf("unlinked wordmark: %s", html)
+	}
+
+	nav := (&navbar.Navbar{LogoSrc: "logo.svg", LogoAlt: "Acme", LogoHref: "index.html", BrandText: "Acme"}).RenderHTML()
+	if !strings.Contains(nav, `<a href="index.html" class="brand navbar-brand"><img class="brand-logo" src="logo.svg" alt="Acme">`) {
+		t.Errorf("navbar does not render the brand:\n%s", nav)
+	}
+	foot := (&footer.Footer{Columns: []footer.FooterColumn{{Content: footer.FooterContent{Type: "about", LogoSrc: "logo.svg", LogoAlt: "Acme"}}}}).RenderHTML()
+	if !strings.Contains(foot, `<span class="brand"><img class="brand-logo" src="logo.svg" alt="Acme"></span>`) {
+		t.Errorf("footer does not render the brand:\n%s", foot)
+	}
+}
+
+func TestNoMissingImageReferences(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Assets"})
+	site.NewPage("Home", "index.html").NewSection("").
+		Add(&navbar.Navbar{ShowSearch: true}).
+		Add(&banner.Banner{Type: banner.BannerTypeQuote, Quote: "Q", Author: "A"}).
+		Add(&contactform.ContactForm{})
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	for _, name := range []string{"out/index.html", "out/style.css"} {
+		for _, ref := range []string{"images/", ".png", "fa-"} {
+			if strings.Contains(files[name], ref) {
+				t.Errorf("%s references missing asset %q", name, ref)
+			}
+		}
+	}
+	if !strings.Contains(files["out/index.html"], `class="navbar-show-btn" aria-label="`) {
+		t.Error("menu button has no accessible name")
+	}
+}
+
+func TestFooterBottomBar(t *testing.T) {
+	if html := (&footer.Footer{}).RenderHTML(); strings.Contains(html, "footer-bottom") {
+		t.Errorf("bottom bar rendered without content:\n%s", html)
+	}
+
+	html := (&footer.Footer{
+		Copyright:   "© {year} Acme <Inc>",
+		BottomLinks: []footer.Link{{Label: "Privacy", Href: `/privacy" onclick="x`}, {Label: "Terms & Co", Href: "/terms"}},
+	}).RenderHTML()
+	year := strconv.Itoa(time.Now().Year())
+	for _, want := range []string{
+		`<span class="footer-copyright">© ` + year + ` Acme &lt;Inc&gt;</span>`,
+		`<a href="/privacy&quot; onclick=&quot;x">Privacy</a>`,
+		`<a href="/terms">Terms &amp; Co</a>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("footer missing %s\ngot:\n%s", want, html)
+		}
+	}
+}
+
+func TestShare(t *testing.T) {
+	html := (&share.Share{URL: "https://example.com/post?id=1&x=2", Title: "Go & \"HTML\" tips"}).RenderHTML()
+	for _, want := range []string{
+		`href="https://twitter.com/intent/tweet?url=https%3A%2F%2Fexample.com%2Fpost%3Fid%3D1%26x%3D2&amp;text=Go%20%26%20%22HTML%22%20tips" target="_blank" rel="noopener noreferrer"`,
+		`href="https://www.facebook.com/sharer/sharer.php?u=https%3A%2F%2Fexample.com%2Fpost%3Fid%3D1%26x%3D2"`,
+		`href="https://wa.me/?text=Go%20%26%20%22HTML%22%20tips%20https%3A%2F%2Fexample.com%2Fpost%3Fid%3D1%26x%3D2"`,
+		`href="mailto:?subject=Go%20%26%20%22HTML%22%20tips&amp;body=https%3A%2F%2Fexample.com%2Fpost%3Fid%3D1%26x%3D2">Email</a>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("share missing %s\ngot:\n%s", want, html)
+		}
+	}
+
+	html = (&share.Share{URL: "https://e.com", Networks: []string{share.LinkedIn, "myspace"}}).RenderHTML()
+	if strings.Count(html, "<a ") != 1 || !strings.Contains(html, "share-offsite/?url=https%3A%2F%2Fe.com") {
+		t.Errorf("want only the LinkedIn link:\n%s", html)
+	}
+}
+
+func TestMapEmbed(t *testing.T) {
+	html := (&mapembed.MapEmbed{URL: `https://maps.example/embed?q=a&z=1" onload="x`, Width: 800, Height: 600, Title: "Our <office>"}).RenderHTML()
+	for _, want := range []string{
+		`style="--map-ratio: 800 / 600"`,
+		`src="https://maps.example/embed?q=a&amp;z=1&quot; onload=&quot;x"`,
+		`title="Our &lt;office&gt;"`,
+		`loading="lazy"`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("map missing %s\ngot:\n%s", want, html)
+		}
+	}
+
+	site, files := newMemorySite(&gosite.Config{Title: "Map", Lang: "EN"})
+	site.NewPage("Contact", "index.html").NewSection("").
+		Add(&contactform.ContactForm{ShowMap: true, MapEmbedURL: "https://maps.example/embed"})
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if !strings.Contains(files["out/index.html"], `<div class="map-embed" style="--map-ratio: 600 / 450"><iframe src="https://maps.example/embed" width="600" height="450" title="Map" loading="lazy"`) {
+		t.Errorf("contact form does not embed the map:\n%s", files["out/index.html"])
+	}
+	if !strings.Contains(files["out/style.css"], "/* Component: MapEmbed */") {
+		t.Error("map CSS not collected from the contact form")
+	}
+}
+
+func TestContactFormPosts(t *testing.T) {
+	html := (&contactform.ContactForm{Action: `/contact" onsubmit="x`}).RenderHTML()
+	for _, want := range []string{
+		`<form action="/contact&quot; onsubmit=&quot;x" method="post">`,
+		`name="name"`, `name="email"`, `name="message"`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("contact form missing %s\ngot:\n%s", want, html)
+		}
+	}
+	if (&contactform.ContactForm{}).RenderJS() == "" {
+		t.Error("contact form has no validation script")
+	}
+}
+
+func TestImagesHaveAlt(t *testing.T) {
+	site := gosite.New(&gosite.Config{Title: "A11y"})
+	site.NewPage("Home", "index.html").NewSection("").
+		Add(&hero.Hero{ImageSrc: "hero.jpg", ImageAlt: "Clinic"}).
+		Add(&banner.Banner{Type: banner.BannerTypeAction, ImageSrc: "b.jpg", ImageAlt: "Team"}).
+		Add(&doctorcard.DoctorCard{Name: "Dr. Bo", ImageSrc: "bo.jpg", ImageAlt: "Dr. Bo"}).
+		Add(&postcard.PostCard{ImageSrc: "p.jpg", ImageAlt: "Post"}).
+		Add(&split.Split{ImageSrc: "s.jpg", ImageAlt: "Split"}).
+		Add(&servicecard.ServiceCard{IconSrc: "i.svg"}).
+		Add(&sectionhead.SectionHead{ShowLineArt: true, DotsImageSrc: "dots.png"}).
+		Add(&brand.Brand{ImageSrc: "logo.svg", Text: "Acme"})
+	files, err := site.GenerateToMap()
+	if err != nil {
+		t.Fatalf("GenerateToMap: %v", err)
+	}
+
+	imgs := regexp.MustCompile(`<img[^>]*>`).FindAllString(files["index.html"], -1)
+	if len(imgs) != 8 {
+		t.Fatalf("found %d images, want 8", len(imgs))
+	}
+	for _, img := range imgs {
+		if !strings.Contains(img, ` alt="`) {
+			t.Errorf("image without alt: %s", img)
+		}
+	}
+	if errs := site.Validate(); len(errs) != 0 {
+		t.Errorf("Validate: %v", errs)
+	}
+	if !strings.Contains(files["index.html"], `role="group" aria-label="Dr. Bo"`) {
+		t.Error("doctor card has no accessible name")
+	}
+
+	site = gosite.New(&gosite.Config{Title: "A11y"})
+	site.NewPage("Home", "index.html").NewSection("").
+		Add(&hero.Hero{ImageSrc: "hero.jpg"})
+	if errs := site.Validate(); len(errs) != 1 || !strings.Contains(errs[0].Error(), "empty alt") {
+		t.Errorf("hero without ImageAlt: Validate = %v, want the ambiguous alt reported", errs)
+	}
+}
+
+func TestCTA(t *testing.T) {
+	html := (&cta.CTA{
+		Heading: "Ready <now>?",
+		Subtext: "Book & go",
+		Buttons: []cta.Button{{Label: "Book", Href: `/book" onclick="x`, CSSClass: "btn-white"}},
+	}).RenderHTML()
+	for _, want := range []string{
+		`<section class="cta text-white text-center bg-blue">`,
+		`<h2 class="lead">Ready &lt;now&gt;?</h2>`,
+		`<p class="text text-md">Book &amp; go</p>`,
+		`<a href="/book&quot; onclick=&quot;x" class="btn btn-white">Book</a>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("cta missing %s\ngot:\n%s", want, html)
+		}
+	}
+
+	html = (&cta.CTA{Heading: "Hi", BgColor: "bg-dark"}).RenderHTML()
+	if !strings.Contains(html, `class="cta text-white text-center bg-dark"`) || strings.Contains(html, "btn-group") {
+		t.Errorf("cta with custom background and no buttons:\n%s", html)
+	}
+}
+
+func TestFAQ(t *testing.T) {
+	html := (&faq.FAQ{Items: []faq.QA{
+		{Question: "Is <b> safe?", Answer: "Yes & \"quoted\""},
+		{Question: "Script?", Answer: "</script><script>x</script>"},
+	}}).RenderHTML()
+	for _, want := range []string{
+		`<summary class="faq-question">Is &lt;b&gt; safe?</summary>`,
+		`<div class="faq-answer text text-md">Yes &amp; &quot;quoted&quot;</div>`,
+		`{"@type":"Question","name":"Is \u003cb\u003e safe?","acceptedAnswer":{"@type":"Answer","text":"Yes \u0026 \"quoted\""}}`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("faq missing %s\ngot:\n%s", want, html)
+		}
+	}
+	if strings.Count(html, "</script>") != 1 {
+		t.Errorf("answer text closes the JSON-LD script:\n%s", html)
+	}
+
+	start := strings.Index(html, `<script type="application/ld+json">`) + len(`<script type="application/ld+json">`)
+	var ld struct {
+		Type       string `json:"@type"`
+		MainEntity []struct {
+			Name           string
+			AcceptedAnswer struct{ Text string }
+		}
+	}
+	if err := json.Unmarshal([]byte(html[start:strings.Index(html, "</script>")]), &ld); err != nil {
+		t.Fatalf("JSON-LD does not parse: %v", err)
+	}
+	if ld.Type != "FAQPage" || len(ld.MainEntity) != 2 || ld.MainEntity[1].AcceptedAnswer.Text != "</script><script>x</script>" {
+		t.Errorf("JSON-LD = %+v", ld)
+	}
+}
+
+func TestDetails(t *testing.T) {
+	html := (&details.Details{Summary: "Spoiler <b>", Content: "It's \"them\" & <i>"}).RenderHTML()
+	want := "<details class=\"details\">\n    <summary>Spoiler &lt;b&gt;</summary>\n    <div class=\"details-content text text-md\">It&#39;s &quot;them&quot; &amp; &lt;i&gt;</div>\n</details>\n"
+	if html != want {
+		t.Errorf("RenderHTML =\n%q\nwant\n%q", html, want)
+	}
+	if html := (&details.Details{Summary: "S", Open: true}).RenderHTML(); !strings.HasPrefix(html, `<details class="details" open>`) {
+		t.Errorf("open details:\n%s", html)
+	}
+}
+
+func TestList(t *testing.T) {
+	html := (&list.List{Items: []list.ListItem{
+		{Text: "Plain <b>"},
+		{Text: "Docs", Href: `/docs?a=1" x="y`},
+		{Text: "Fast", Icon: "bolt", Href: "/fast"},
+	}}).RenderHTML()
+	for _, want := range []string{
+		`<ul class="list">`,
+		`<li>Plain &lt;b&gt;</li>`,
+		`<li><a href="/docs?a=1&quot; x=&quot;y">Docs</a></li>`,
+		`<li><a href="/fast"><svg class="icon list-icon" aria-hidden="true" focusable="false"><use href="icons.svg#bolt"></use></svg>Fast</a></li>`,
+		"</ul>",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("list missing %s\ngot:\n%s", want, html)
+		}
+	}
+	if html := (&list.List{Ordered: true, Items: []list.ListItem{{Text: "1"}}}).RenderHTML(); !strings.HasPrefix(html, `<ol class="list list-ordered">`) {
+		t.Errorf("ordered list:\n%s", html)
+	}
+
+	site, files := newMemorySite(&gosite.Config{Title: "List"})
+	site.NewPage("Home", "index.html").NewSection("").Add(&list.List{Items: []list.ListItem{{Text: "A", Icon: "check"}}})
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if !strings.Contains(files["out/style.css"], "/* Component: Icon */") {
+		t.Error("icon CSS not collected from the list")
+	}
+}
+
+func TestCountdown(t *testing.T) {
+	setLang(t, "EN")
+
+	html := (&countdown.Countdown{TargetISO: "2030-01-02T03:04:05-03:00", ExpiredText: `We're <live>`}).RenderHTML()
+	for _, want := range []string{
+		`role="timer" data-target="2030-01-02T06:04:05Z" data-expired="We&#39;re &lt;live&gt;"`,
+		`<time class="countdown-boxes" datetime="2030-01-02T06:04:05Z">`,
+		`<span class="countdown-value" data-unit="seconds">--</span>`,
+		`<span class="countdown-label text-sm">Days</span>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("countdown missing %s\ngot:\n%s", want, html)
+		}
+	}
+	if html := (&countdown.Countdown{TargetISO: "2030-01-02T03:04"}).RenderHTML(); !strings.Contains(html, `data-target="2030-01-02T03:04:00Z"`) {
+		t.Errorf("target without offset must be UTC:\n%s", html)
+	}
+	html = (&countdown.Countdown{TargetISO: "next friday", ExpiredText: "Over"}).RenderHTML()
+	if html != "<div class=\"countdown countdown-expired\">Over</div>\n" {
+		t.Errorf("invalid target = %q", html)
+	}
+}
+
+func TestDivider(t *testing.T) {
+	if html := (&divider.Divider{}).RenderHTML(); html != "<hr class=\"divider\">\n" {
+		t.Errorf("plain divider = %q", html)
+	}
+	if html := (&divider.Divider{Style: "dashed"}).RenderHTML(); !strings.Contains(html, `class="divider divider-dashed"`) {
+		t.Errorf("dashed divider = %q", html)
+	}
+	if html := (&divider.Divider{Style: `x" onclick="y`}).RenderHTML(); html != "<hr class=\"divider\">\n" {
+		t.Errorf("unknown style must be ignored: %q", html)
+	}
+	html := (&divider.Divider{Label: "<OR>"}).RenderHTML()
+	if !strings.Contains(html, `<div class="divider divider-labeled" role="separator"><span class="text-sm">&lt;OR&gt;</span></div>`) {
+		t.Errorf("labeled divider = %q", html)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	html := (&quote.Quote{
+		Text:       "Less is <more>",
+		Author:     "Mies & co",
+		Source:     "Interviews",
+		SourceHref: `https://example.com/a?b=1" x="y`,
+	}).RenderHTML()
+	for _, want := range []string{
+		`<blockquote cite="https://example.com/a?b=1&quot; x=&quot;y">`,
+		`<p>Less is &lt;more&gt;</p>`,
+		`<figcaption class="text text-sm"><span class="quote-author">Mies &amp; co</span>, <cite><a href="https://example.com/a?b=1&quot; x=&quot;y">Interviews</a></cite></figcaption>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("quote missing %s\ngot:\n%s", want, html)
+		}
+	}
+
+	html = (&quote.Quote{Text: "Hi", Source: "Book"}).RenderHTML()
+	if !strings.Contains(html, "<blockquote>") || !strings.Contains(html, "<figcaption class=\"text text-sm\"><cite>Book</cite></figcaption>") || strings.Contains(html, "<a ") {
+		t.Errorf("quote without SourceHref must not link:\n%s", html)
+	}
+	if html := (&quote.Quote{Text: "Hi"}).RenderHTML(); strings.Contains(html, "figcaption") {
+		t.Errorf("quote without author or source renders a caption:\n%s", html)
+	}
+}
+
+func TestCodeBlock(t *testing.T) {
+	src := "func main() {\n\tif a < b && c {\n\t\t\"x\"\n\t}\n}\n"
+	site, files := newMemorySite(&gosite.Config{Title: "Code", Lang: "EN"})
+	site.NewPage("Docs", "index.html").NewSection("").
+		Add(&codeblock.CodeBlock{Language: "go", Content: src, ShowCopy: true})
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	html := files["out/index.html"]
+	want := `<pre><code class="language-go">func main() {` + "\n\t" + `if a &lt; b &amp;&amp; c {` + "\n\t\t" + `&quot;x&quot;` + "\n\t}\n}\n" + `</code></pre>`
+	if !strings.Contains(html, want) {
+		t.Errorf("code whitespace or escaping changed, want %q in:\n%s", want, html)
+	}
+	if !strings.Contains(html, `<button type="button" class="code-copy" data-copied="Copied">Copy</button>`) {
+		t.Errorf("copy button missing:\n%s", html)
+	}
+	if !strings.Contains(files["out/script.js"], "navigator.clipboard.writeText") {
+		t.Error("copy script not bundled")
+	}
+
+	if html := (&codeblock.CodeBlock{Content: "x"}).RenderHTML(); strings.Contains(html, "code-copy") || !strings.Contains(html, "<pre><code>x</code></pre>") {
+		t.Errorf("code block without ShowCopy or Language:\n%s", html)
+	}
+}
+
+// spanHighlighter wraps Go code in a span, standing in for a real tokenizer.
+type spanHighlighter struct{}
+
+func (spanHighlighter) Highlight(language, code string) (string, bool) {
+	if language != "go" {
+		return "", false
+	}
+	return `<span class="hl">` + html.EscapeString(code) + `</span>`, true
+}
+
+func TestCodeBlockHighlighting(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Code", SyntaxHighlight: true, CSPNonce: "n0"})
+	site.NewPage("Docs", "index.html").NewSection("").
+		Add(&codeblock.CodeBlock{Language: "go", Content: "a < b", Highlighter: spanHighlighter{}}).
+		Add(&codeblock.CodeBlock{Language: "js", Content: "a < b", Highlighter: spanHighlighter{}})
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	page := files["out/index.html"]
+	for _, want := range []string{
+		`<pre><code class="language-go nohighlight"><span class="hl">a &lt; b</span></code></pre>`,
+		`<pre><code class="language-js">a &lt; b</code></pre>`,
+		`highlight.js/11.9.0/highlight.min.js`,
+		`highlight.js/11.9.0/styles/github.min.css`,
+		`<script nonce="n0">document.addEventListener('DOMContentLoaded'`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("page missing %s\n%s", want, page)
+		}
+	}
+
+	site, files = newMemorySite(&gosite.Config{Title: "Code"})
+	site.NewPage("Docs", "index.html").NewSection("").Add(&codeblock.CodeBlock{Language: "go", Content: "x"})
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if strings.Contains(files["out/index.html"], "highlight.js") {
+		t.Error("highlight.js loaded with SyntaxHighlight off")
+	}
+}
+
+func TestHeroBackgroundSlides(t *testing.T) {
+	html := (&hero.Hero{Title: "Hi", BgImages: []string{"a.jpg", `b".jpg`, "c.jpg"}}).RenderHTML()
+	for _, want := range []string{
+		`<link rel="preload" as="image" href="a.jpg">`,
+		`<header class="header bg-blue header-bg-image header-has-slides" style="--hero-overlay: 0.5;">`,
+		`<div class="header-slide active" style="background-image: url(&quot;a.jpg&quot;);"></div>`,
+		`<div class="header-slide" data-bg="url(&quot;b\&quot;.jpg&quot;)"></div>`,
+		`<div class="header-slide" data-bg="url(&quot;c.jpg&quot;)"></div>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("hero missing %s\ngot:\n%s", want, html)
+		}
+	}
+	if strings.Count(html, "background-image") != 1 {
+		t.Errorf("only the first slide should load eagerly:\n%s", html)
+	}
+	if !strings.Contains((&hero.Hero{}).RenderJS(), "setInterval") {
+		t.Error("hero has no slide script")
+	}
+
+	if html := (&hero.Hero{BgImageSrc: "bg.jpg"}).RenderHTML(); strings.Contains(html, "header-slides") || strings.Contains(html, "preload") {
+		t.Errorf("single background renders slides:\n%s", html)
+	}
+}
+
+func TestHeroVariants(t *testing.T) {
+	html := (&hero.Hero{Variants: []string{"Ship faster", "Build <better>"}, TitleSpan: "with Go"}).RenderHTML()
+	for _, want := range []string{
+		`<header class="header bg-blue" data-variant="0">`,
+		`<h1><span class="header-variant" data-variant="0">Ship faster</span><span class="header-variant" data-variant="1" hidden>Build &lt;better&gt;</span><br> <span>with Go</span></h1>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("hero missing %s\ngot:\n%s", want, html)
+		}
+	}
+	if !strings.Contains((&hero.Hero{}).RenderJS(), "localStorage.setItem") {
+		t.Error("hero script does not persist the variant")
+	}
+
+	single := (&hero.Hero{Variants: []string{"Only"}}).RenderHTML()
+	if single != (&hero.Hero{Title: "Only"}).RenderHTML() {
+		t.Errorf("single variant differs from a plain title:\n%s", single)
+	}
+}
+
+func TestThemeOverride(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Theme"})
+	site.NewPage("Pricing", "index.html").NewSection("").
+		Add(&cta.CTA{Heading: "Plain"}).
+		Add(&themeoverride.ThemeOverride{
+			Colors: map[string]string{
+				"primary":                          "#e63946",
+				"--fros-blue":                      `"x"`,
+				"secondary":                        "red; color: blue",
+				"primary: red; background: url(x)": "#000",
+				"Text":                             "#111",
+			},
+			Children: []themeoverride.HTMLRenderer{&cta.CTA{Heading: "Highlighted"}},
+		})
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	html := files["out/index.html"]
+	want := `<div class="theme-override" style="--fros-blue: &quot;x&quot;; --color-primary: #e63946;"><section class="cta text-white text-center bg-blue"><div class="container"><h2 class="lead">Highlighted</h2>`
+	if !strings.Contains(html, want) {
+		t.Errorf("missing %s\n%s", want, html)
+	}
+	if strings.Contains(html, "color: blue") {
+		t.Error("value with a declaration separator was not skipped")
+	}
+	if strings.Contains(html, "url(x)") || strings.Contains(html, "#111") {
+		t.Error("invalid variable name was not skipped")
+	}
+	if strings.Count(html, "--color-primary") != 1 {
+		t.Error("override leaked outside the wrapper")
+	}
+	if css := files["out/style.css"]; !strings.Contains(css, "/* Component: CTA */") || !strings.Contains(css, ".theme-override {") {
+		t.Error("wrapper or child CSS not collected")
+	}
+}
@@ -3,17 +3,37 @@
 package gosite
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"os"
+	"sync"
+
 	. "github.com/cdvelop/tinystring"
 )
 
 // Site manages the global state of the website for the backend.
 // It includes fields for asset management and file generation.
+//
+// Generate and GenerateToMap may be called from several goroutines: rendering
+// registers navbar assets, so generation is serialized. Building the site
+// (NewPage, Add...) is not synchronized and must finish before generating.
+//
+// Assets are kept in slices, never iterated from a map: style.css and
+// script.js list blocks in registration order, so generating the same site
+// twice yields byte-identical files.
 type Site struct {
+	mu        sync.Mutex // serializes generate
 	Cfg       *Config
 	pages     []*Page
-	cssBlocks []assetBlock
-	jsBlocks  []assetBlock
-	buff      *Conv
+	cssBlocks []assetBlock        // insertion-ordered CSS
+	jsBlocks  []assetBlock        // insertion-ordered JS
+	globalCSS []assetBlock        // AddGlobalCSS blocks, bundled after cssBlocks
+	globalJS  []assetBlock        // AddGlobalJS blocks, bundled after jsBlocks
+	cssHashes map[string]struct{} // SHA-256 (or CSSKey) of every CSS block, for dedup
+	jsHashes  map[string]struct{} // SHA-256 of every JS block, for dedup
+	icons     []iconSymbol        // insertion-ordered sprite symbols
+	registry  map[string]ComponentFactory
 }
 
 // New creates a new site manager for the backend.
@@ -26,60 +46,286 @@ func New(cfg *Config) *Site {
 		pages:     make([]*Page, 0),
 		cssBlocks: make([]assetBlock, 0),
 		jsBlocks:  make([]assetBlock, 0),
-		buff:      Convert(),
+		cssHashes: make(map[string]struct{}),
+		jsHashes:  make(map[string]struct{}),
 	}
 }
 
 // AddCSS accumulates CSS with deduplication at the site level.
 func (s *Site) AddCSS(css string) {
-	if css == "" {
+	s.cssBlocks = addAsset(s.cssBlocks, s.cssHashes, hashString(css), css)
+}
+
+// AddCSSWithKey accumulates CSS deduplicated by key instead of content: only
+// the first block registered under a key is kept (see CSSKeyRenderer).
+func (s *Site) AddCSSWithKey(key, css string) {
+	if key == "" {
+		s.AddCSS(css)
 		return
 	}
-	for _, existing := range s.cssBlocks {
-		if existing.Content == css {
-			return // Already added, skip duplicate
-		}
-	}
-	s.cssBlocks = append(s.cssBlocks, assetBlock{Content: css})
+	s.cssBlocks = addAsset(s.cssBlocks, s.cssHashes, "key:"+key, css)
 }
 
 // AddJS accumulates JavaScript with deduplication at the site level.
 func (s *Site) AddJS(js string) {
-	if js == "" {
-		return
+	s.jsBlocks = addAsset(s.jsBlocks, s.jsHashes, hashString(js), js)
+}
+
+// AddGlobalCSS adds site-wide CSS not tied to a component, deduplicated like
+// AddCSS. Global CSS is written after the base styles and every component
+// block, whenever those are registered, so its rules can override them.
+func (s *Site) AddGlobalCSS(css string) {
+	s.globalCSS = addAsset(s.globalCSS, s.cssHashes, "global:"+hashString(css), css)
+}
+
+// AddGlobalJS adds site-wide JavaScript not tied to a component, deduplicated
+// like AddJS. Global JS runs after every component script.
+func (s *Site) AddGlobalJS(js string) {
+	s.globalJS = addAsset(s.globalJS, s.jsHashes, "global:"+hashString(js), js)
+}
+
+// bundleJS returns the JS blocks in bundle order: components, then globals.
+func (s *Site) bundleJS() []assetBlock {
+	return append(append([]assetBlock(nil), s.jsBlocks...), s.globalJS...)
+}
+
+// AddIcon registers an SVG symbol for the icons.svg sprite, referenced as
+// icons.svg#name. svgBody is the inner markup of a 24x24 viewBox, e.g. the
+// <path> elements. A name already registered is ignored.
+func (s *Site) AddIcon(name, svgBody string) {
+	for _, ic := range s.icons {
+		if ic.Name == name {
+			return
+		}
+	}
+	s.icons = append(s.icons, iconSymbol{Name: name, Body: svgBody})
+}
+
+// addAsset appends content to blocks unless a block with the same hash was
+// already added. Lookup is O(1); blocks keeps the insertion order.
+func addAsset(blocks []assetBlock, hashes map[string]struct{}, hash, content string) []assetBlock {
+	if content == "" {
+		return blocks
+	}
+	if _, exists := hashes[hash]; exists {
+		return blocks // Already added, skip duplicate
+	}
+	hashes[hash] = struct{}{}
+	return append(blocks, assetBlock{Hash: hash, Content: content})
+}
+
+// hashString returns the hex-encoded SHA-256 of s.
+func hashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// JSBlocks returns the deduplicated JS blocks in bundle order. With
+// Config.InlineCriticalJS each block is one inline <script>, so a build step
+// can hash them for a CSP script-src header.
+func (s *Site) JSBlocks() []string {
+	bundle := s.bundleJS()
+	blocks := make([]string, len(bundle))
+	for i, b := range bundle {
+		blocks[i] = b.Content
+	}
+	return blocks
+}
+
+// CriticalCSS returns the CSS inlined in every page head when
+// Config.InlineCriticalCSS is enabled: the base variables and reset, plus the
+// site nav styles when the nav is rendered. It is "" otherwise.
+func (s *Site) CriticalCSS() string {
+	if !s.Cfg.InlineCriticalCSS {
+		return ""
+	}
+	css := s.generateBaseCSS()
+	if s.PageCount() > 1 {
+		css += (&NavbarBuilder{site: s}).RenderCSS()
 	}
-	for _, existing := range s.jsBlocks {
-		if existing.Content == js {
-			return // Already added, skip duplicate
+	return s.finishCSS(css)
+}
+
+// JSModules returns the ES modules page p loads when Config.ESModules is
+// enabled, in bundle order: the scripts of its own components plus the
+// site-wide blocks (navbar, scroll reveal, AddGlobalJS) no component owns.
+// Files are named by content hash, prefixed with the JSKey when set, so a
+// change to one component leaves the other module URLs cached.
+func (s *Site) JSModules(p *Page) []JSModule {
+	if !s.Cfg.ESModules || s.Cfg.InlineCriticalJS {
+		return nil
+	}
+	owned := make(map[string]bool) // scripts of any page component
+	for _, page := range s.pages {
+		for _, section := range page.sections {
+			for _, c := range section.scripts {
+				owned[hashString(c.js)] = true
+			}
 		}
 	}
-	s.jsBlocks = append(s.jsBlocks, assetBlock{Content: js})
+	keys := make(map[string]string) // scripts of p, by hash, to their key
+	for _, section := range p.sections {
+		for _, c := range section.scripts {
+			if key, ok := keys[hashString(c.js)]; !ok || key == "" {
+				keys[hashString(c.js)] = c.key
+			}
+		}
+	}
+
+	var modules []JSModule
+	for _, b := range s.bundleJS() {
+		hash := hashString(b.Content)
+		key, used := keys[hash]
+		if owned[hash] && !used {
+			continue
+		}
+		name := hash[:12]
+		if key != "" {
+			name = key + "-" + hash[:8]
+		}
+		modules = append(modules, JSModule{Key: key, Src: "js/" + name + ".js", content: b.Content})
+	}
+	return modules
+}
+
+// mergeAssets accumulates other's CSS/JS blocks, skipping duplicates.
+func (s *Site) mergeAssets(other *Site) {
+	for _, b := range other.cssBlocks {
+		s.cssBlocks = addAsset(s.cssBlocks, s.cssHashes, b.Hash, b.Content)
+	}
+	for _, b := range other.jsBlocks {
+		s.jsBlocks = addAsset(s.jsBlocks, s.jsHashes, b.Hash, b.Content)
+	}
+	for _, b := range other.globalCSS {
+		s.globalCSS = addAsset(s.globalCSS, s.cssHashes, b.Hash, b.Content)
+	}
+	for _, b := range other.globalJS {
+		s.globalJS = addAsset(s.globalJS, s.jsHashes, b.Hash, b.Content)
+	}
+	for _, ic := range other.icons {
+		s.AddIcon(ic.Name, ic.Body)
+	}
 }
 
 // Generate renders all site files to disk.
 func (s *Site) Generate() error {
+	return s.generate(func(name, content string) error {
+		return s.Cfg.WriteFile(PathJoin(s.Cfg.OutputDir, name).String(), content)
+	})
+}
+
+// GenerateToMap renders all site files into memory instead of disk.
+// Keys are file names relative to OutputDir (e.g. "index.html", "style.css").
+func (s *Site) GenerateToMap() (map[string]string, error) {
+	files := make(map[string]string)
+	err := s.generate(func(name, content string) error {
+		files[name] = content
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// generate renders every site file and hands it to write.
+// Pages are rendered first because rendering may register nav assets.
+func (s *Site) generate(write func(name, content string) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	defer useLang(s.Cfg)()
+
+	if err := s.copyStaticDir(write); err != nil {
+		return err
+	}
+
+	// Static files are copied as-is; only generated files are precompressed.
+	write, err := s.precompressWriter(write)
+	if err != nil {
+		return err
+	}
+
+	if s.Cfg.ScrollReveal {
+		// Registered before the pages so inline and module scripts include it.
+		s.AddCSS(scrollRevealCSS)
+		s.AddJS(scrollRevealJS)
+	}
+
 	for _, page := range s.pages {
-		pagePath := PathJoin(s.Cfg.OutputDir, page.filename).String()
-		if err := s.Cfg.WriteFile(pagePath, page.RenderHTML()); err != nil {
+		if err := write(page.filename, page.RenderHTML()); err != nil {
 			// In Go, it's conventional to return errors rather than panic.
 			// The caller can decide how to handle the error.
 			return err
 		}
 	}
 
-	if err := s.writeCSSFile(); err != nil {
+	if err := s.writeCSSFile(write); err != nil {
+		return err
+	}
+	if err := s.writeJSFile(write); err != nil {
 		return err
 	}
-	if err := s.writeJSFile(); err != nil {
+	if err := s.writeJSModules(write); err != nil {
+		return err
+	}
+	if err := s.writeIconSprite(write); err != nil {
 		return err
 	}
 	return nil
 }
 
+// copyStaticDir writes every file under Config.StaticDir keeping its path
+// relative to the directory, e.g. "img/logo.png". Generated files written
+// afterwards take precedence over static files with the same name.
+func (s *Site) copyStaticDir(write func(name, content string) error) error {
+	if s.Cfg.StaticDir == "" {
+		return nil
+	}
+	root := os.DirFS(s.Cfg.StaticDir)
+	return fs.WalkDir(root, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return Err("static:", err.Error())
+		}
+		if d.IsDir() {
+			return nil
+		}
+		data, err := fs.ReadFile(root, name)
+		if err != nil {
+			return Err("static:", err.Error())
+		}
+		return write(name, string(data))
+	})
+}
+
+// reset clears all pages and accumulated assets so the site can be rebuilt.
+func (s *Site) reset() {
+	s.pages = make([]*Page, 0)
+	s.cssBlocks = make([]assetBlock, 0)
+	s.jsBlocks = make([]assetBlock, 0)
+	s.globalCSS = nil
+	s.globalJS = nil
+	s.cssHashes = make(map[string]struct{})
+	s.jsHashes = make(map[string]struct{})
+	s.icons = nil
+}
+
 // generateBaseCSS generates the base CSS with variables and reset styles.
 func (s *Site) generateBaseCSS() string {
 	cs := s.Cfg.ColorScheme
-	tpl := `:root {
+	heading := cs.Heading
+	if heading == "" {
+		heading = cs.Primary
+	}
+	maxWidth := s.Cfg.Layout.MaxWidth
+	if maxWidth == "" {
+		maxWidth = "1200px"
+	}
+	sectionPadding := s.Cfg.Layout.SectionPadding
+	if sectionPadding == "" {
+		sectionPadding = "2rem"
+	}
+	tpl := `%s:root {
 	--color-primary: %s;
 	--color-secondary: %s;
 	--color-text: %s;
@@ -87,42 +333,159 @@ func (s *Site) generateBaseCSS() string {
 	--color-border: %s;
 	--color-heading: %s;
 	--color-card-bg: %s;
+	--layout-max-width: %s;
+	--section-padding: %s;
 }
 *, *::before, *::after { box-sizing: border-box; margin: 0; padding: 0; }
-body { font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; background: var(--color-background); color: var(--color-text); line-height: 1.6; }
-section { padding: 2rem; max-width: 1200px; margin: 0 auto; }
+body { font-family: %s; background: var(--color-background); color: var(--color-text); line-height: 1.6; }
+section { padding: var(--section-padding); max-width: var(--layout-max-width); margin: 0 auto; }
+.container { width: 100%%; max-width: var(--layout-max-width); margin: 0 auto; padding: 0 1.5rem; }
 h1 { color: var(--color-heading); font-size: 2.5rem; margin-bottom: 1.5rem; text-align: center; }
 h2 { color: var(--color-heading); font-size: 2rem; margin-bottom: 1rem; }
 .card-container { display: grid; grid-template-columns: repeat(auto-fit, minmax(300px, 1fr)); gap: 1.5rem; margin-top: 2rem; }
 `
-	return Fmt(tpl, cs.Primary, cs.Secondary, cs.Text, cs.Background, cs.Border, cs.Primary, cs.Background)
+	return Fmt(tpl, renderFontFaces(s.Cfg.Fonts), cs.Primary, cs.Secondary, cs.Text, cs.Background, cs.Border, heading, cs.Background, maxWidth, sectionPadding, bodyFontFamily(s.Cfg))
 }
 
+// baseUtilityCSS defines the shared layout and typography classes used by
+// the layout components (hero, banner, footer, contactform...). It follows
+// the base CSS so component stylesheets can override it.
+const baseUtilityCSS = `.flex { display: flex; flex-wrap: wrap; align-items: center; gap: 1rem; }
+.grid { display: grid; gap: 2rem; }
+@media (min-width: 768px) { .grid { grid-template-columns: repeat(2, 1fr); } }
+.py { padding: 4rem 0; }
+.bg-blue { background-color: var(--color-primary); }
+.text-white { color: #fff; }
+.text-white h1, .text-white h2, .text-white h3 { color: inherit; }
+.text-center { text-align: center; }
+.lead { font-size: 1.5rem; font-weight: 600; line-height: 1.3; }
+.text { opacity: 0.9; }
+.text-sm { font-size: 0.875rem; }
+.text-md { font-size: 1rem; }
+.text-lg { font-size: 1.25rem; }
+.btn-group { display: flex; flex-wrap: wrap; gap: 1rem; }
+.btn { display: inline-block; padding: 0.75rem 1.5rem; border: 2px solid transparent; border-radius: 4px; font-weight: 600; text-decoration: none; cursor: pointer; transition: opacity 0.2s; }
+.btn:hover { opacity: 0.85; }
+.btn-blue { background: var(--color-primary); color: #fff; }
+.btn-light-blue { background: transparent; border-color: #fff; color: #fff; }
+.btn-white { background: #fff; color: var(--color-primary); }
+.icon-inline { display: inline-block; width: 1em; height: 1em; vertical-align: -0.125em; }
+`
+
+// basePrintCSS hides the site chrome and prints the content full width in
+// black on white.
+const basePrintCSS = `@media print {
+nav, .main-nav, .navbar, footer, .footer, .navbar-show-btn, .search-bar { display: none !important; }
+body { color: #000 !important; background: #fff !important; }
+.content, section { max-width: none; width: 100%; padding: 0; margin: 0; }
+.card-container { display: block; }
+a[href^="http"]::after { content: " (" attr(href) ")"; }
+}
+`
+
 // writeCSSFile writes the combined CSS to a file.
-func (s *Site) writeCSSFile() error {
-	if len(s.cssBlocks) == 0 {
+func (s *Site) writeCSSFile(write func(name, content string) error) error {
+	if len(s.cssBlocks) == 0 && len(s.globalCSS) == 0 {
 		return nil // No CSS to write
 	}
-	s.buff.Reset()
-	s.buff.Write(s.generateBaseCSS())
+	// A fresh buffer per file: String() releases it back to the pool.
+	buf := Convert()
+	buf.Write(s.generateBaseCSS())
+	buf.Write(baseUtilityCSS)
 	for _, b := range s.cssBlocks {
-		s.buff.Write(b.Content)
-		s.buff.Write("\n")
+		buf.Write(b.Content)
+		buf.Write("\n")
+	}
+	if s.Cfg.PrintStyles {
+		buf.Write(basePrintCSS)
+	}
+	for _, b := range s.globalCSS {
+		buf.Write(b.Content)
+		buf.Write("\n")
+	}
+	return write("style.css", s.finishCSS(buf.String()))
+}
+
+// finishCSS applies the configured breakpoints and prefixes to css.
+func (s *Site) finishCSS(css string) string {
+	css = applyBreakpoints(css, s.Cfg.Breakpoints)
+	if s.Cfg.Autoprefix {
+		css = autoprefixCSS(css)
 	}
-	cssPath := PathJoin(s.Cfg.OutputDir, "style.css").String()
-	return s.Cfg.WriteFile(cssPath, s.buff.String())
+	return css
+}
+
+// defaultBreakpoints are the widths the component stylesheets are written with.
+var defaultBreakpoints = Breakpoints{Mobile: "768px", Tablet: "992px", Desktop: "1200px"}
+
+// applyBreakpoints rewrites the media queries of css from the default widths
+// to the configured ones; empty fields keep their default. Placeholders make
+// the rewrite single-pass, so e.g. Mobile "992px" is not then moved to Tablet.
+func applyBreakpoints(css string, bp Breakpoints) string {
+	pairs := [][2]string{
+		{defaultBreakpoints.Mobile, bp.Mobile},
+		{defaultBreakpoints.Tablet, bp.Tablet},
+		{defaultBreakpoints.Desktop, bp.Desktop},
+	}
+	features := []string{"(min-width: ", "(max-width: "}
+	for i, p := range pairs {
+		if p[1] == "" || p[1] == p[0] {
+			continue
+		}
+		for _, feature := range features {
+			css = Convert(css).Replace(feature+p[0]+")", Fmt("%s\x00%d)", feature, i)).String()
+		}
+	}
+	for i, p := range pairs {
+		for _, feature := range features {
+			css = Convert(css).Replace(Fmt("%s\x00%d)", feature, i), feature+p[1]+")").String()
+		}
+	}
+	return css
 }
 
 // writeJSFile writes the combined JS to a file.
-func (s *Site) writeJSFile() error {
-	if len(s.jsBlocks) == 0 {
-		return nil // No JS to write
+func (s *Site) writeJSFile(write func(name, content string) error) error {
+	bundle := s.bundleJS()
+	if len(bundle) == 0 || s.Cfg.InlineCriticalJS {
+		return nil // No JS to write, or already inlined in the pages
+	}
+	buf := Convert()
+	for _, b := range bundle {
+		buf.Write(b.Content)
+		buf.Write("\n")
+	}
+	return write("script.js", buf.String())
+}
+
+// writeJSModules writes the ES module files loaded by the pages, once each.
+// The combined script.js is still written as the nomodule fallback.
+func (s *Site) writeJSModules(write func(name, content string) error) error {
+	written := make(map[string]bool)
+	for _, p := range s.pages {
+		for _, m := range s.JSModules(p) {
+			if written[m.Src] {
+				continue
+			}
+			written[m.Src] = true
+			if err := write(m.Src, m.content); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeIconSprite writes icons.svg with every registered symbol.
+func (s *Site) writeIconSprite(write func(name, content string) error) error {
+	if len(s.icons) == 0 {
+		return nil // No icons registered
 	}
-	s.buff.Reset()
-	for _, b := range s.jsBlocks {
-		s.buff.Write(b.Content)
-		s.buff.Write("\n")
+	b := Convert()
+	b.Write(`<svg xmlns="http://www.w3.org/2000/svg">` + "\n")
+	for _, ic := range s.icons {
+		b.Write(Fmt(`<symbol id="%s" viewBox="0 0 24 24">%s</symbol>`+"\n", Convert(ic.Name).EscapeAttr(), ic.Body))
 	}
-	jsPath := PathJoin(s.Cfg.OutputDir, "script.js").String()
-	return s.Cfg.WriteFile(jsPath, s.buff.String())
+	b.Write("</svg>\n")
+	return write("icons.svg", b.String())
 }
@@ -2,11 +2,18 @@
 
 package gosite
 
+import (
+	"syscall/js"
+
+	. "github.com/cdvelop/tinystring"
+)
+
 // Site manages the global state of the website for the frontend (WASM).
 // It's a lightweight version focused on rendering, not file generation.
 type Site struct {
-	Cfg   *Config
-	pages []*Page
+	Cfg      *Config
+	pages    []*Page
+	registry map[string]ComponentFactory
 }
 
 // New creates a new site manager for the frontend.
@@ -24,10 +31,58 @@ func New(cfg *Config) *Site {
 // CSS is handled by the stylesheet generated by the backend.
 func (s *Site) AddCSS(css string) {}
 
+// AddCSSWithKey is a no-op in the frontend.
+func (s *Site) AddCSSWithKey(key, css string) {}
+
 // AddJS is a no-op in the frontend.
 // JS is handled by the script generated by the backend.
 func (s *Site) AddJS(js string) {}
 
+// AddGlobalCSS is a no-op in the frontend.
+func (s *Site) AddGlobalCSS(css string) {}
+
+// AddGlobalJS is a no-op in the frontend.
+func (s *Site) AddGlobalJS(js string) {}
+
+// AddIcon is a no-op in the frontend.
+// The icons.svg sprite is generated by the backend.
+func (s *Site) AddIcon(name, svgBody string) {}
+
+// JSBlocks returns nil in the frontend; JS is bundled by the backend.
+func (s *Site) JSBlocks() []string { return nil }
+
+// CriticalCSS returns "" in the frontend; style.css is generated by the backend.
+func (s *Site) CriticalCSS() string { return "" }
+
+// JSModules returns nil in the frontend; no script files are generated.
+func (s *Site) JSModules(p *Page) []JSModule { return nil }
+
+// mergeAssets is a no-op in the frontend.
+func (s *Site) mergeAssets(other *Site) {}
+
+// Mount renders the sections of a page into the DOM element with the given id,
+// replacing its content. The EventRenderer components recorded by Section.Add
+// are then bound with Config.EventBinder, so they work without the script
+// generated by the backend.
+func (s *Site) Mount(elementID string, p *Page) error {
+	el := js.Global().Get("document").Call("getElementById", elementID)
+	if el.IsNull() || el.IsUndefined() {
+		return Err("element", elementID, D.Not, D.Found)
+	}
+	defer useLang(s.Cfg)()
+	el.Set("innerHTML", p.renderSections())
+
+	if s.Cfg.EventBinder == nil {
+		return nil
+	}
+	for _, section := range p.sections {
+		for _, c := range section.events {
+			c.BindEvents(s.Cfg.EventBinder)
+		}
+	}
+	return nil
+}
+
 // Generate is not available in the frontend.
 // This function is backend-specific and would cause a compile error if called.
 // func (s *Site) Generate() error { ... }
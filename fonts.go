@@ -0,0 +1,80 @@
+package gosite
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// systemFontStack is the body font used when no font is configured.
+const systemFontStack = "-apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif"
+
+// fontFormats maps font file extensions to their @font-face format() hint.
+var fontFormats = map[string]string{
+	".woff2": "woff2",
+	".woff":  "woff",
+	".ttf":   "truetype",
+	".otf":   "opentype",
+}
+
+// renderFontFaces returns an @font-face rule per configured font.
+func renderFontFaces(fonts []FontFace) string {
+	b := Convert()
+	for _, f := range fonts {
+		b.Write("@font-face { font-family: '")
+		b.Write(cssQuoted(f.Family))
+		b.Write("'; src: url('")
+		b.Write(cssQuoted(f.Src))
+		b.Write("')")
+		if format, ok := fontFormats[Convert(f.Src).PathExt().ToLower().String()]; ok {
+			b.Write(" format('" + format + "')")
+		}
+		b.Write(";")
+		if f.Weight != "" {
+			b.Write(" font-weight: " + f.Weight + ";")
+		}
+		if f.Style != "" {
+			b.Write(" font-style: " + f.Style + ";")
+		}
+		b.Write(" font-display: swap; }\n")
+	}
+	return b.String()
+}
+
+// bodyFontFamily returns the body font-family: the first configured font (or
+// Google Fonts family) followed by the system stack as fallback.
+func bodyFontFamily(cfg *Config) string {
+	family := ""
+	if len(cfg.Fonts) > 0 {
+		family = cfg.Fonts[0].Family
+	} else if len(cfg.GoogleFonts) > 0 {
+		family = Convert(cfg.GoogleFonts[0]).Split(":")[0]
+	}
+	if family == "" {
+		return systemFontStack
+	}
+	return "'" + cssQuoted(family) + "', " + systemFontStack
+}
+
+// renderGoogleFontsLinks returns the <head> links loading the given Google
+// Fonts families with a single css2 request.
+func renderGoogleFontsLinks(families []string) string {
+	if len(families) == 0 {
+		return ""
+	}
+	href := Convert("https://fonts.googleapis.com/css2?")
+	for _, family := range families {
+		href.Write("family=")
+		href.Write(Convert(family).Replace(" ", "+").String())
+		href.Write("&")
+	}
+	href.Write("display=swap")
+
+	return `  <link rel="preconnect" href="https://fonts.googleapis.com">
+  <link rel="preconnect" href="https://fonts.gstatic.com" crossorigin>
+  <link rel="stylesheet" href="` + Convert(href.String()).EscapeAttr() + `">
+`
+}
+
+// cssQuoted strips characters that would end a quoted CSS string.
+func cssQuoted(s string) string {
+	return Convert(s).Replace("'", "").Replace("\\", "").Replace("\n", "").String()
+}
@@ -0,0 +1,54 @@
+//go:build !wasm
+
+package gosite
+
+import (
+	"archive/zip"
+	"io"
+
+	. "github.com/cdvelop/tinystring"
+)
+
+// WriteFS is a writable file system targeted by GenerateToFS, e.g. an
+// in-memory FS, an object store or an archive. Names are slash-separated and
+// relative to the site root ("index.html", "img/logo.png").
+type WriteFS interface {
+	WriteFile(name string, data []byte) error
+}
+
+// GenerateToFS renders all site files into fsys instead of Config.WriteFile.
+// Config.OutputDir is not used: names are relative to the root of fsys.
+func (s *Site) GenerateToFS(fsys WriteFS) error {
+	return s.generate(func(name, content string) error {
+		return fsys.WriteFile(name, []byte(content))
+	})
+}
+
+// GenerateZip renders all site files into a single zip archive written to w.
+func (s *Site) GenerateZip(w io.Writer) error {
+	zw := zip.NewWriter(w)
+	if err := s.GenerateToFS(zipFS{zw}); err != nil {
+		zw.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return Err("zip:", err.Error())
+	}
+	return nil
+}
+
+// zipFS adds each written file as a deflated zip entry.
+type zipFS struct {
+	zw *zip.Writer
+}
+
+func (z zipFS) WriteFile(name string, data []byte) error {
+	f, err := z.zw.Create(name)
+	if err != nil {
+		return Err("zip:", name, err.Error())
+	}
+	if _, err := f.Write(data); err != nil {
+		return Err("zip:", name, err.Error())
+	}
+	return nil
+}
@@ -1,5 +1,11 @@
 package gosite
 
+import (
+	"sync"
+
+	. "github.com/cdvelop/tinystring"
+)
+
 // ColorScheme holds the basic color configuration for the site.
 type ColorScheme struct {
 	Primary    string
@@ -7,6 +13,7 @@ type ColorScheme struct {
 	Text       string
 	Background string
 	Border     string
+	Heading    string // Optional: heading color, defaults to Primary
 }
 
 // DefaultColorScheme returns the default color scheme.
@@ -20,18 +27,129 @@ func DefaultColorScheme() *ColorScheme {
 	}
 }
 
+// Script loading strategies for Config.ScriptLoading.
+const (
+	ScriptDefer  = "defer"  // <script defer>: download in parallel, run after parsing (default)
+	ScriptSync   = "sync"   // plain <script>, blocking at the end of body
+	ScriptModule = "module" // <script type="module">, deferred by the browser
+)
+
+// JSModule is an ES module file written with Config.ESModules.
+type JSModule struct {
+	Key     string // JSKeyRenderer key, mapped by the page import map; "" for unkeyed blocks
+	Src     string // File name, e.g. "js/carousel-1a2b3c4d.js"
+	content string
+}
+
+// defaultLang is the output language when Config.Lang is empty.
+const defaultLang = "ES"
+
+// outputLang returns the language code of cfg: Config.Lang or defaultLang.
+func outputLang(cfg *Config) string {
+	if cfg.Lang == "" {
+		return defaultLang
+	}
+	return cfg.Lang
+}
+
+// langMu is held while a site renders with its language, so sites generated
+// concurrently never see each other's.
+var langMu sync.Mutex
+
+// useLang selects the translation language of cfg and returns a func that
+// restores the previous one. The tinystring language is process-wide, so each
+// site selects its own only while rendering, holding langMu until restore.
+func useLang(cfg *Config) (restore func()) {
+	langMu.Lock()
+	prev := OutLang(nil) // a non-string argument reads without changing it
+	OutLang(outputLang(cfg))
+	return func() {
+		OutLang(prev)
+		langMu.Unlock()
+	}
+}
+
+// Page transitions for Config.PageTransition.
+const (
+	TransitionFade  = "fade"  // cross-fade the page content (default)
+	TransitionSlide = "slide" // slide the old page out to the left and the new one in from the right
+	TransitionNone  = "none"  // no View Transition CSS or JS; links navigate normally
+)
+
+// FontFace describes a self-hosted font emitted as an @font-face rule.
+type FontFace struct {
+	Family string // e.g. "Inter"
+	Src    string // font file URL, e.g. "fonts/inter.woff2"
+	Weight string // Optional: e.g. "400", "100 900"
+	Style  string // Optional: e.g. "normal", "italic"
+}
+
+// Layout sets the content width and spacing of the base CSS.
+type Layout struct {
+	MaxWidth       string // Width of sections and .container, default "1200px"; "none" for full width
+	SectionPadding string // Padding of each section, default "2rem"
+}
+
+// Breakpoints sets the viewport widths of the responsive media queries. The
+// component stylesheets are written against the defaults; the generated
+// style.css rewrites their (min-width: ...) and (max-width: ...) queries to
+// the configured values.
+type Breakpoints struct {
+	Mobile  string // Upper bound of the mobile layout, default "768px"
+	Tablet  string // default "992px"
+	Desktop string // default "1200px"
+}
+
+// Analytics sets the tracking code injected into every generated page.
+// Snippets are emitted verbatim, so they must come from a trusted source.
+type Analytics struct {
+	GA4MeasurementID string // Optional: Google Analytics 4 ID, e.g. "G-XXXXXXXXXX", loaded with the standard gtag snippet
+	RequireConsent   bool   // Hold the GA4 and snippet scripts as type="text/plain" data-consent until a "consent:accept" event on document
+	HeadSnippet      string // Optional: raw markup appended to every <head>
+	BodySnippet      string // Optional: raw markup appended before every </body>
+}
+
 // Config holds the configuration for the site.
 // It uses build tags to include environment-specific fields.
 type Config struct {
-	Title       string
-	OutputDir   string
-	ColorScheme *ColorScheme
-	EventBinder EventBinder // Frontend only
-	WriteFile   func(path string, content string) error // Backend only
+	Title             string
+	Lang              string // Output language of <html lang> and the built-in UI strings, e.g. "EN" (see tinystring.OutLang); default "ES"
+	OutputDir         string
+	ColorScheme       *ColorScheme
+	EventBinder       EventBinder                             // Frontend only
+	WriteFile         func(path string, content string) error // Backend only
+	RUMEndpoint       string                                  // Optional: URL receiving Core Web Vitals beacons (LCP/CLS/INP)
+	PrettyHTML        bool                                    // Indent generated HTML; when false (default) inter-tag whitespace is stripped
+	ESModules         bool                                    // Emit each JS block as a content-hashed ES module loaded only by the pages using it, with an import map; script.js remains as nomodule fallback
+	ScriptLoading     string                                  // ScriptDefer (default), ScriptSync or ScriptModule for the script.js tag
+	PageTransition    string                                  // TransitionFade (default), TransitionSlide or TransitionNone for navigation between pages
+	InlineCriticalJS  bool                                    // Inline each JS block in its own <script> instead of writing script.js (for strict CSP)
+	InlineCriticalCSS bool                                    // Inline the base and nav CSS in each <head> and load style.css without blocking render
+	CSPNonce          string                                  // Optional: nonce attribute added to every generated <script> tag and the critical <style>
+	Fonts             []FontFace                              // Optional: @font-face rules; the first family becomes the body font
+	GoogleFonts       []string                                // Optional: Google Fonts families, e.g. "Inter:wght@400;700", linked from every page head
+	BaseURL           string                                  // Optional: absolute site URL, e.g. "https://example.com", prefixed to relative canonical/alternate URLs
+	Autoprefix        bool                                    // Add -webkit-/-moz- copies of a fixed set of properties (user-select, appearance, position: sticky...) to style.css
+	ScrollReveal      bool                                    // Fade in elements marked data-reveal (components' Reveal field) as they scroll into view
+	PrintStyles       bool                                    // Append an @media print block to style.css (and collect PrintCSSRenderer rules)
+	StaticDir         string                                  // Optional: directory copied recursively into OutputDir by Generate; WriteFile then receives nested paths, e.g. "out/img/logo.png"
+	Precompress       []string                                // Optional: encodings (PrecompressGzip) also written for generated HTML/CSS/JS/SVG files of at least 1 KiB, e.g. "style.css.gz"
+	Layout            Layout                                  // Optional: content max width and section padding
+	Breakpoints       Breakpoints                             // Optional: media query widths replacing the 768px/992px/1200px defaults
+	IconLibrary       string                                  // IconsInline (default), IconsFontAwesome or IconsNone for the <i class="fa-..."> icons of components
+	ThemeColor        string                                  // Optional: <meta name="theme-color"> for the mobile browser chrome, default ColorScheme.Primary
+	SyntaxHighlight   bool                                    // Load highlight.js from a CDN to color <code class="language-x"> blocks at runtime (default off)
+	Viewport          string                                  // Optional: viewport meta content, default "width=device-width, initial-scale=1.0"
+	Analytics         Analytics                               // Optional: GA4 or custom tracking snippets for every page
 }
 
 // NewPage creates a new page and registers it with the site.
+// An empty filename is derived from the title, e.g. "About Us" => "about_us.html",
+// adding -2, -3... when the name is already taken.
 func (s *Site) NewPage(title, filename string) *Page {
+	if filename == "" {
+		filename = s.uniqueFilename(Convert(title).SnakeLow().String())
+	}
 	p := &Page{
 		site:     s,
 		title:    title,
@@ -43,17 +161,112 @@ func (s *Site) NewPage(title, filename string) *Page {
 	return p
 }
 
+// uniqueFilename returns slug + ".html", suffixed with -2, -3... until no
+// registered page uses it.
+func (s *Site) uniqueFilename(slug string) string {
+	if slug == "" {
+		slug = "page"
+	}
+	name := slug + ".html"
+	for n := 2; s.hasPage(name); n++ {
+		name = Fmt("%s-%d.html", slug, n)
+	}
+	return name
+}
+
+func (s *Site) hasPage(filename string) bool {
+	for _, p := range s.pages {
+		if p.filename == filename {
+			return true
+		}
+	}
+	return false
+}
+
+// Config returns the site configuration.
+func (s *Site) Config() *Config {
+	return s.Cfg
+}
+
 // PageCount returns the number of pages in the site.
 func (s *Site) PageCount() int {
 	return len(s.pages)
 }
 
+// Merge appends the pages of other to the site and accumulates its CSS/JS,
+// so a site can be composed from independently built feature sub-sites.
+//
+// Conflict resolution: page filenames must be unique across both sites,
+// including within other. On any collision Merge returns an error and leaves
+// the site unchanged. CSS/JS blocks present in both sites are deduplicated
+// and keep the receiver's order. Components registered on other are added to
+// the receiver's registry; a name registered on both keeps the receiver's
+// factory. other's Config is ignored.
+// The merged pages are re-attached to the receiver, so other should not be
+// generated on its own afterwards.
+func (s *Site) Merge(other *Site) error {
+	seen := make(map[string]bool, len(s.pages)+len(other.pages))
+	for _, p := range s.pages {
+		seen[p.filename] = true
+	}
+	for _, op := range other.pages {
+		if seen[op.filename] {
+			return Err(D.Page, op.filename, "already registered")
+		}
+		seen[op.filename] = true
+	}
+
+	for _, op := range other.pages {
+		op.site = s
+		for _, section := range op.sections {
+			section.site = s
+		}
+		s.pages = append(s.pages, op)
+	}
+	for name, factory := range other.registry {
+		if _, ok := s.registry[name]; !ok {
+			s.RegisterComponent(name, factory)
+		}
+	}
+	s.mergeAssets(other)
+	return nil
+}
+
+// Clone returns a copy of the site for variant builds, e.g. the same pages
+// generated with another ColorScheme, BaseURL or OutputDir. The config, pages,
+// sections and collected assets are copied, so changing the clone leaves s
+// untouched; component values added to sections are shared.
+func (s *Site) Clone() *Site {
+	cfg := *s.Cfg
+	if s.Cfg.ColorScheme != nil {
+		scheme := *s.Cfg.ColorScheme
+		cfg.ColorScheme = &scheme
+	}
+	cfg.Fonts = append([]FontFace(nil), s.Cfg.Fonts...)
+	cfg.GoogleFonts = append([]string(nil), s.Cfg.GoogleFonts...)
+	cfg.Precompress = append([]string(nil), s.Cfg.Precompress...)
+
+	c := New(&cfg)
+	for name, factory := range s.registry {
+		c.RegisterComponent(name, factory)
+	}
+	for _, p := range s.pages {
+		c.pages = append(c.pages, p.clone(c))
+	}
+	c.mergeAssets(s)
+	return c
+}
+
 // BuildNav creates the navigation menu.
 // This is a shared method, as nav structure is the same in both environments.
 func (s *Site) BuildNav() string {
 	nav := &NavbarBuilder{site: s}
 	// In the backend, this will add CSS/JS. In frontend, it's a no-op.
 	s.AddCSS(nav.RenderCSS())
-	s.AddJS(nav.RenderJS())
+	if js := nav.RenderJS(); js != "" {
+		s.AddJS(js)
+	}
+	s.AddJS(nav.RenderSmoothScrollJS())
+	s.AddJS(nav.RenderScrollSpyJS())
 	return nav.Render()
 }
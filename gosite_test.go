@@ -1,14 +1,48 @@
 package gosite_test
 
 import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/cdvelop/gosite"
 	"github.com/cdvelop/gosite/components/card"
+	"github.com/cdvelop/gosite/components/carousel"
+	"github.com/cdvelop/gosite/components/content/avatar"
+	"github.com/cdvelop/gosite/components/content/codeblock"
+	"github.com/cdvelop/gosite/components/content/countdown"
+	"github.com/cdvelop/gosite/components/content/details"
+	"github.com/cdvelop/gosite/components/content/doctorcard"
+	"github.com/cdvelop/gosite/components/content/faq"
+	"github.com/cdvelop/gosite/components/content/icon"
+	"github.com/cdvelop/gosite/components/content/list"
+	"github.com/cdvelop/gosite/components/content/mapembed"
+	"github.com/cdvelop/gosite/components/content/packagecard"
+	"github.com/cdvelop/gosite/components/content/postcard"
+	"github.com/cdvelop/gosite/components/content/quote"
+	"github.com/cdvelop/gosite/components/content/sectionhead"
+	"github.com/cdvelop/gosite/components/content/servicecard"
+	"github.com/cdvelop/gosite/components/content/share"
+	"github.com/cdvelop/gosite/components/forms/contactform"
 	"github.com/cdvelop/gosite/components/forms/form"
+	"github.com/cdvelop/gosite/components/layout/banner"
+	"github.com/cdvelop/gosite/components/layout/cta"
+	"github.com/cdvelop/gosite/components/layout/divider"
+	"github.com/cdvelop/gosite/components/layout/footer"
+	"github.com/cdvelop/gosite/components/layout/grid"
+	"github.com/cdvelop/gosite/components/layout/hero"
+	"github.com/cdvelop/gosite/components/layout/themeoverride"
+	"github.com/cdvelop/gosite/components/navigation/backtotop"
+	"github.com/cdvelop/gosite/components/navigation/navbar"
+	"github.com/cdvelop/gosite/components/navigation/progressbar"
 )
 
 func TestGenerateExample(t *testing.T) {
@@ -140,3 +174,919 @@ func TestGenerateExample(t *testing.T) {
 	t.Logf("✓ HTML files: %d", len(htmlFiles))
 	t.Logf("✓ CSS file size: %d bytes", len(cssContent))
 }
+
+func TestMergeSites(t *testing.T) {
+	files := make(map[string]string)
+	cfg := &gosite.Config{
+		Title:     "Merged",
+		OutputDir: "out",
+		WriteFile: func(path, content string) error {
+			files[path] = content
+			return nil
+		},
+	}
+
+	main := gosite.New(cfg)
+	main.NewPage("Home", "index.html").NewSection("Home").Add(&card.Card{Title: "Home card"})
+
+	blog := gosite.New(&gosite.Config{})
+	blog.NewPage("Blog", "blog.html").NewSection("Posts").
+		Add(&card.Card{Title: "Post card"}).
+		Add(&form.Form{Config: form.Config{Action: "/subscribe", Method: "POST"}})
+
+	if err := main.Merge(blog); err != nil {
+		t.Fatalf("Merge returned error: %v", err)
+	}
+	if main.PageCount() != 2 {
+		t.Fatalf("expected 2 pages after merge, got %d", main.PageCount())
+	}
+	if err := main.Generate(); err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+
+	if !strings.Contains(files["out/blog.html"], "Post card") {
+		t.Error("merged page not generated")
+	}
+	if !strings.Contains(files["out/index.html"], `href="blog.html"`) {
+		t.Error("nav of original page does not link to merged page")
+	}
+	css := files["out/style.css"]
+	if strings.Count(css, ".card {") != 1 {
+		t.Errorf("card CSS should appear once, got %d", strings.Count(css, ".card {"))
+	}
+	if !strings.Contains(css, ".contact-form") {
+		t.Error("merged form CSS missing")
+	}
+
+	dup := gosite.New(&gosite.Config{})
+	dup.NewPage("Other Home", "index.html")
+	if err := main.Merge(dup); err == nil {
+		t.Error("expected error merging a colliding filename")
+	}
+	if main.PageCount() != 2 {
+		t.Errorf("failed merge modified the site: %d pages", main.PageCount())
+	}
+
+	twice := gosite.New(&gosite.Config{})
+	twice.NewPage("News", "news.html")
+	twice.NewPage("More news", "news.html")
+	if err := main.Merge(twice); err == nil {
+		t.Error("expected error merging a site with duplicate filenames")
+	}
+	if main.PageCount() != 2 {
+		t.Errorf("failed merge modified the site: %d pages", main.PageCount())
+	}
+
+	widgets := gosite.New(&gosite.Config{})
+	widgets.RegisterComponent("banner", func(props map[string]any) gosite.HTMLRenderer {
+		return &card.Card{Title: gosite.PropString(props, "title")}
+	})
+	widgets.NewPage("Widgets", "widgets.html")
+	if err := main.Merge(widgets); err != nil {
+		t.Fatalf("Merge returned error: %v", err)
+	}
+	if _, err := main.NewComponent("banner", map[string]any{"title": "Hi"}); err != nil {
+		t.Errorf("component registered on merged site not available: %v", err)
+	}
+}
+
+// TestCSSHasNoLineComments guards against JavaScript-style // comments in
+// component CSS, which are invalid CSS and break the following rule.
+func TestCSSHasNoLineComments(t *testing.T) {
+	files := make(map[string]string)
+	site := gosite.New(&gosite.Config{
+		OutputDir: "out",
+		WriteFile: func(path, content string) error {
+			files[path] = content
+			return nil
+		},
+	})
+	site.NewPage("Home", "index.html").NewSection("All").
+		Add(&card.Card{}).
+		Add(&carousel.Carousel{}).
+		Add(&form.Form{}).
+		Add(&contactform.ContactForm{}).
+		Add(&doctorcard.DoctorCard{}).
+		Add(&packagecard.PackageCard{}).
+		Add(&postcard.PostCard{}).
+		Add(&sectionhead.SectionHead{}).
+		Add(&servicecard.ServiceCard{}).
+		Add(&icon.Icon{}).
+		Add(&avatar.Avatar{}).
+		Add(&share.Share{}).
+		Add(&faq.FAQ{}).
+		Add(&details.Details{}).
+		Add(&quote.Quote{}).
+		Add(&countdown.Countdown{}).
+		Add(&list.List{}).
+		Add(&codeblock.CodeBlock{}).
+		Add(&mapembed.MapEmbed{}).
+		Add(&banner.Banner{}).
+		Add(&cta.CTA{}).
+		Add(&themeoverride.ThemeOverride{}).
+		Add(&divider.Divider{}).
+		Add(&footer.Footer{}).
+		Add(&hero.Hero{}).
+		Add(&navbar.Navbar{}).
+		Add(&backtotop.BackToTop{}).
+		Add(&progressbar.ProgressBar{})
+	site.NewPage("About", "about.html")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	css := files["out/style.css"]
+	for i := strings.Index(css, "url("); i >= 0; i = strings.Index(css, "url(") {
+		end := strings.Index(css[i:], ")")
+		if end < 0 {
+			break
+		}
+		css = css[:i] + css[i+end+1:]
+	}
+	for _, line := range strings.Split(css, "\n") {
+		if strings.Contains(line, "//") {
+			t.Errorf("stray // comment in CSS: %q", strings.TrimSpace(line))
+		}
+	}
+}
+
+// BenchmarkAddCSS adds the CSS of 500 distinct components, each one twice,
+// exercising the hash-based deduplication lookup.
+func BenchmarkAddCSS(b *testing.B) {
+	blocks := make([]string, 500)
+	for i := range blocks {
+		blocks[i] = strings.Repeat(".component-"+strconv.Itoa(i)+" { padding: 1rem; }\n", 20)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		site := gosite.New(&gosite.Config{})
+		for _, css := range blocks {
+			site.AddCSS(css)
+		}
+		for _, css := range blocks {
+			site.AddCSS(css)
+		}
+	}
+}
+
+func TestGridCollectsChildAssets(t *testing.T) {
+	files := make(map[string]string)
+	site := gosite.New(&gosite.Config{
+		OutputDir: "out",
+		WriteFile: func(path, content string) error {
+			files[path] = content
+			return nil
+		},
+	})
+	site.NewPage("Home", "index.html").NewSection("Features").Add(&grid.Grid{
+		Columns: 3,
+		Gap:     "2rem",
+		Children: []grid.HTMLRenderer{
+			&card.Card{Title: "One"},
+			&carousel.Carousel{},
+		},
+	})
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	html := files["out/index.html"]
+	if !strings.Contains(html, `class="grid-layout grid-fixed" style="--grid-columns: 3; --grid-gap: 2rem;"`) {
+		t.Errorf("grid wrapper not rendered:\n%s", html)
+	}
+	if !strings.Contains(html, "<h3>One</h3>") {
+		t.Error("grid child not rendered")
+	}
+	css := files["out/style.css"]
+	if !strings.Contains(css, ".grid-layout") || !strings.Contains(css, ".card {") || !strings.Contains(css, ".carousel {") {
+		t.Error("grid or child CSS not collected")
+	}
+	if !strings.Contains(files["out/script.js"], "Carousel auto-slide") {
+		t.Error("child JS not collected")
+	}
+}
+
+func TestIconSprite(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Icons"})
+	site.NewPage("Home", "index.html").NewSection("Icons").
+		Add(&icon.Icon{Name: "star"}).
+		Add(&icon.Icon{Name: "heart", Title: "Favorite", Size: 32})
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if _, ok := files["out/icons.svg"]; ok {
+		t.Error("icons.svg written without registered icons")
+	}
+
+	html := files["out/index.html"]
+	for _, want := range []string{
+		`<svg class="icon" aria-hidden="true" focusable="false"><use href="icons.svg#star"></use></svg>`,
+		`<svg class="icon" style="width:32px;height:32px" role="img"><title>Favorite</title><use href="icons.svg#heart"></use></svg>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("page missing %s", want)
+		}
+	}
+
+	site, files = newMemorySite(&gosite.Config{Title: "Icons"})
+	site.AddIcon("star", `<path d="M12 2l3 7h7l-6 4 2 7-6-4-6 4 2-7-6-4h7z"/>`)
+	site.AddIcon("star", `<path d="ignored"/>`)
+	site.NewPage("Home", "index.html")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	sprite := files["out/icons.svg"]
+	if !strings.Contains(sprite, `<symbol id="star" viewBox="0 0 24 24"><path d="M12 2l3 7h7l-6 4 2 7-6-4-6 4 2-7-6-4h7z"/></symbol>`) {
+		t.Errorf("sprite missing star symbol:\n%s", sprite)
+	}
+	if strings.Contains(sprite, "ignored") {
+		t.Error("duplicate icon name registered twice")
+	}
+}
+
+// keyedCSS renders CSS that varies per instance but shares a CSSKey.
+type keyedCSS struct{ color string }
+
+func (k keyedCSS) RenderHTML() string { return "<div class=\"keyed\"></div>" }
+func (k keyedCSS) RenderCSS() string  { return ".keyed { color: " + k.color + "; }" }
+func (k keyedCSS) CSSKey() string     { return "keyed" }
+
+func TestCSSKeyDedup(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Keys"})
+	site.NewPage("Home", "index.html").NewSection("S").
+		Add(keyedCSS{color: "red"}).
+		Add(keyedCSS{color: "blue"}).
+		Add(&card.Card{})
+	site.AddCSS(".keyed { color: blue; }") // not keyed: deduplicated by content only
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	css := files["out/style.css"]
+	if !strings.Contains(css, ".keyed { color: red; }") {
+		t.Error("first keyed block missing")
+	}
+	if n := strings.Count(css, ".keyed { color: blue; }"); n != 1 {
+		t.Errorf("unkeyed block count = %d, want 1", n)
+	}
+}
+
+func TestComponentRegistry(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Registry"})
+	site.RegisterComponent("card", func(props map[string]any) gosite.HTMLRenderer {
+		return &card.Card{
+			Title:       gosite.PropString(props, "title"),
+			Description: gosite.PropString(props, "description"),
+		}
+	})
+
+	section := site.NewPage("Home", "index.html").NewSection("Cards")
+	if err := section.AddByName("card", map[string]any{"title": "From CMS", "description": 42}); err != nil {
+		t.Fatalf("AddByName: %v", err)
+	}
+	if err := section.AddByName("missing", nil); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("expected error naming the unregistered component, got %v", err)
+	}
+
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	html := files["out/index.html"]
+	if !strings.Contains(html, "<h3>From CMS</h3><p>42</p>") {
+		t.Errorf("registered card not rendered:\n%s", html)
+	}
+	if !strings.Contains(files["out/style.css"], ".card {") {
+		t.Error("registered component CSS not collected")
+	}
+}
+
+func TestLoadFromJSON(t *testing.T) {
+	newSite := func() (*gosite.Site, map[string]string) {
+		site, files := newMemorySite(&gosite.Config{Title: "JSON"})
+		site.RegisterComponent("card", func(props map[string]any) gosite.HTMLRenderer {
+			return &card.Card{Title: gosite.PropString(props, "title")}
+		})
+		return site, files
+	}
+
+	site, files := newSite()
+	err := site.LoadFromJSON([]byte(`{"pages": [
+		{"title": "Home", "filename": "index.html", "sections": [
+			{"title": "Services", "id": "svc", "components": [{"name": "card", "props": {"title": "Web"}}]}
+		]},
+		{"title": "About Us"}
+	]}`))
+	if err != nil {
+		t.Fatalf("LoadFromJSON: %v", err)
+	}
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if html := files["out/index.html"]; !strings.Contains(html, `<section id="svc" class="page">`) || !strings.Contains(html, "<h3>Web</h3>") {
+		t.Errorf("index.html not built from JSON:\n%s", html)
+	}
+	if _, ok := files["out/about_us.html"]; !ok {
+		t.Error("page without filename not generated")
+	}
+
+	for doc, want := range map[string]string{
+		`{"pages": [`:                         "json:",
+		`{"pages": []}`:                       "pages",
+		`{"pages": [{"filename": "x.html"}]}`: "pages[0].title",
+		`{"pages": [{"title": "A", "sections": [{"components": [{"name": "card"}, {}]}]}]}`:   "pages[0].sections[0].components[1].name",
+		`{"pages": [{"title": "A", "sections": [{}, {"components": [{"name": "slider"}]}]}]}`: "pages[0].sections[1].components[0].name: component slider",
+	} {
+		site, _ := newSite()
+		err := site.LoadFromJSON([]byte(doc))
+		if err == nil || !strings.HasPrefix(err.Error(), want) {
+			t.Errorf("LoadFromJSON(%s) error = %v, want prefix %q", doc, err, want)
+		}
+		if site.PageCount() != 0 {
+			t.Errorf("LoadFromJSON(%s) added pages despite the error", doc)
+		}
+	}
+}
+
+func TestAddMarkdownPage(t *testing.T) {
+	path := t.TempDir() + "/about.md"
+	md := "---\ntitle: About Us\nfilename: about.html\ndescription: \"Who & why\"\n---\n" +
+		"# Hello\n\nSome **bold** and *em* text with `a<b` and a [link](/x?a=1&b=2).\n\n" +
+		"[x](javascript:alert(1)) [y]( JaVa\tScript:alert(2)) ![i](data:image/svg+xml,<svg>) [w](https://en.wikipedia.org/wiki/Go_(language)).\n\n" +
+		"- one\n- two\n\n1. first\n\n```go\nfmt.Println(\"<hi>\")\n```\n\n<script>alert(1)</script>\n"
+	if err := os.WriteFile(path, []byte(md), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	site, files := newMemorySite(&gosite.Config{Title: "MD"})
+	if err := site.AddMarkdownPage(path); err != nil {
+		t.Fatalf("AddMarkdownPage: %v", err)
+	}
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	html := files["out/about.html"]
+	for _, want := range []string{
+		`<meta name="description" content="Who &amp; why">`,
+		"<h1>Hello</h1>",
+		"<p>Some <strong>bold</strong> and <em>em</em> text with <code>a&lt;b</code> and a <a href=\"/x?a=1&amp;b=2\">link</a>.</p>",
+		`<p><a href="#">x</a> <a href="#">y</a> i <a href="https://en.wikipedia.org/wiki/Go_(language)">w</a>.</p>`,
+		"<ul><li>one</li><li>two</li></ul>",
+		"<ol><li>first</li></ol>",
+		`<pre><code class="language-go">fmt.Println(&quot;&lt;hi&gt;&quot;)</code></pre>`,
+		"<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("about.html missing %q:\n%s", want, html)
+		}
+	}
+
+	if strings.Contains(html, "javascript:") || strings.Contains(html, "JaVa") || strings.Contains(html, "data:image") {
+		t.Errorf("unsafe URL scheme rendered:\n%s", html)
+	}
+
+	if err := os.WriteFile(path, []byte("# No front matter\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := site.AddMarkdownPage(path); err == nil {
+		t.Error("AddMarkdownPage without a title: want error")
+	}
+}
+
+func TestSiteClone(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "AB", OutputDir: "a"})
+	site.NewPage("Home", "index.html").NewSection("Offer").Add(&card.Card{Title: "Plan A"})
+
+	variant := site.Clone()
+	variant.Cfg.OutputDir = "b"
+	variant.Cfg.ColorScheme.Primary = "#123456"
+	variant.NewPage("Extra", "extra.html")
+
+	site.Cfg.Precompress = []string{gosite.PrecompressGzip}
+	site.Cfg.GoogleFonts = []string{"Inter"}
+	clone := site.Clone()
+	clone.Cfg.Precompress[0] = "br"
+	clone.Cfg.GoogleFonts[0] = "Roboto"
+	if site.Cfg.Precompress[0] != gosite.PrecompressGzip || site.Cfg.GoogleFonts[0] != "Inter" {
+		t.Error("clone config slices share their backing array with the original")
+	}
+	site.Cfg.Precompress, site.Cfg.GoogleFonts = nil, nil
+
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate original: %v", err)
+	}
+	if err := variant.Generate(); err != nil {
+		t.Fatalf("Generate clone: %v", err)
+	}
+
+	if !strings.Contains(files["b/index.html"], "<h3>Plan A</h3>") || !strings.Contains(files["b/style.css"], ".card {") {
+		t.Error("clone lost the original pages or assets")
+	}
+	if !strings.Contains(files["b/style.css"], "#123456") || strings.Contains(files["a/style.css"], "#123456") {
+		t.Error("clone color scheme not independent from the original")
+	}
+	if _, ok := files["a/extra.html"]; ok || site.PageCount() != 1 {
+		t.Error("page added to the clone leaked into the original")
+	}
+	if strings.Contains(files["a/index.html"], "extra.html") {
+		t.Error("original nav lists a page added to the clone")
+	}
+}
+
+func TestStaticDirCopiedOnGenerate(t *testing.T) {
+	static := t.TempDir()
+	if err := os.MkdirAll(static+"/img/icons", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{"robots.txt": "User-agent: *", "img/icons/logo.svg": "<svg></svg>"} {
+		if err := os.WriteFile(static+"/"+name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	site, files := newMemorySite(&gosite.Config{Title: "Static", StaticDir: static})
+	site.NewPage("Home", "index.html")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if files["out/robots.txt"] != "User-agent: *" || files["out/img/icons/logo.svg"] != "<svg></svg>" {
+		t.Errorf("static files not copied with their structure: %v", files)
+	}
+
+	site, _ = newMemorySite(&gosite.Config{Title: "Static", StaticDir: static + "/missing"})
+	if err := site.Generate(); err == nil {
+		t.Error("Generate with a missing StaticDir: want error")
+	}
+}
+
+func TestPrecompress(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Gzip", Precompress: []string{gosite.PrecompressGzip}})
+	site.NewPage("Home", "index.html").NewSection("Big").Add(&card.Card{Title: strings.Repeat("a", 2000)})
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	for _, name := range []string{"index.html", "style.css"} {
+		gz, ok := files["out/"+name+".gz"]
+		if !ok {
+			t.Errorf("%s.gz not written", name)
+			continue
+		}
+		zr, err := gzip.NewReader(strings.NewReader(gz))
+		if err != nil {
+			t.Fatalf("%s.gz: %v", name, err)
+		}
+		data, err := io.ReadAll(zr)
+		if err != nil || string(data) != files["out/"+name] {
+			t.Errorf("%s.gz does not decompress to the original (err %v)", name, err)
+		}
+	}
+
+	// A lone page has no nav and stays below the threshold
+	site, files = newMemorySite(&gosite.Config{Title: "Gzip", Precompress: []string{gosite.PrecompressGzip}})
+	site.NewPage("Tiny", "tiny.html")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if _, ok := files["out/tiny.html.gz"]; ok {
+		t.Error("file below the size threshold was compressed")
+	}
+
+	site, _ = newMemorySite(&gosite.Config{Title: "Brotli", Precompress: []string{gosite.PrecompressBrotli}})
+	site.NewPage("Home", "index.html")
+	if err := site.Generate(); err == nil || !strings.Contains(err.Error(), "br") {
+		t.Errorf("Generate with brotli: err = %v, want unsupported error", err)
+	}
+}
+
+func TestConcurrentGenerate(t *testing.T) {
+	var mu sync.Mutex
+	files := make(map[string]string)
+	site := gosite.New(&gosite.Config{
+		Title:     "Race",
+		OutputDir: "out",
+		WriteFile: func(path, content string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			files[path] = content
+			return nil
+		},
+	})
+	site.NewPage("Home", "index.html").NewSection("Cards").Add(&card.Card{Title: "One"})
+	site.NewPage("About", "about.html")
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i == 0 {
+				errs[i] = site.Generate()
+			} else {
+				_, errs[i] = site.GenerateToMap()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent generate: %v", err)
+		}
+	}
+	if !strings.Contains(files["out/index.html"], "<h3>One</h3>") {
+		t.Error("index.html not generated")
+	}
+}
+
+func TestConcurrentGenerateKeepsEachSiteLang(t *testing.T) {
+	build := func(lang string) *gosite.Site {
+		site := gosite.New(&gosite.Config{Title: "Lang", Lang: lang})
+		site.NewPage("Home", "index.html").NewSection("Contact").Add(&form.Form{})
+		return site
+	}
+	en := build("EN")
+	es := en.Clone()
+	es.Cfg.Lang = "ES"
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		for site, want := range map[*gosite.Site]string{en: "Send Message", es: "Enviar Mensaje"} {
+			wg.Add(1)
+			go func(site *gosite.Site, want string) {
+				defer wg.Done()
+				files, err := site.GenerateToMap()
+				if err != nil {
+					t.Errorf("GenerateToMap: %v", err)
+					return
+				}
+				if !strings.Contains(files["index.html"], want) {
+					t.Errorf("%s site rendered in another language", site.Cfg.Lang)
+				}
+			}(site, want)
+		}
+	}
+	wg.Wait()
+}
+
+// memFS is a WriteFS keeping files in memory.
+type memFS map[string][]byte
+
+func (m memFS) WriteFile(name string, data []byte) error {
+	m[name] = data
+	return nil
+}
+
+func TestGenerateToFSAndZip(t *testing.T) {
+	site := gosite.New(&gosite.Config{Title: "FS", OutputDir: "ignored"})
+	site.NewPage("Home", "index.html").NewSection("Cards").Add(&card.Card{Title: "Zipped"})
+
+	fsys := memFS{}
+	if err := site.GenerateToFS(fsys); err != nil {
+		t.Fatalf("GenerateToFS: %v", err)
+	}
+	if !strings.Contains(string(fsys["index.html"]), "<h3>Zipped</h3>") || len(fsys["style.css"]) == 0 {
+		t.Errorf("GenerateToFS files: %v", fsys)
+	}
+
+	var buf bytes.Buffer
+	if err := site.GenerateZip(&buf); err != nil {
+		t.Fatalf("GenerateZip: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader: %v", err)
+	}
+	names := map[string]bool{}
+	for _, f := range zr.File {
+		names[f.Name] = true
+		if f.Name == "index.html" {
+			rc, _ := f.Open()
+			data, _ := io.ReadAll(rc)
+			rc.Close()
+			if !strings.Contains(string(data), "<h3>Zipped</h3>") {
+				t.Error("zipped index.html content mismatch")
+			}
+		}
+	}
+	if !names["index.html"] || !names["style.css"] {
+		t.Errorf("zip entries = %v", names)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	site := gosite.New(&gosite.Config{Title: "Valid"})
+	site.NewPage("Home", "index.html").NewSection("Cards").
+		Add(&card.Card{Title: "A > B", Description: "x < y"}).
+		Add(&carousel.Carousel{Images: []carousel.CarouselImage{{Src: "a.jpg", Alt: "A"}}})
+	site.NewPage("About", "about.html").NewSection("About")
+	if errs := site.Validate(); len(errs) != 0 {
+		t.Errorf("Validate on clean output: %v", errs)
+	}
+
+	site.NewPage("Broken", "broken.html").NewSection("Broken").
+		AddRaw(`<div id="broken">1 > 0</div><a href="">x</a><a>y</a><img src="p.png"><img src="q.png" alt=""><img src="r.png" alt="" role="presentation">`)
+	errs := site.Validate()
+	want := []string{
+		`broken.html: duplicate id "broken"`,
+		`broken.html: unescaped ">" in text`,
+		`broken.html: <a> with empty href`,
+		`broken.html: <a> with empty href`,
+		`broken.html: <img> without alt`,
+		`broken.html: <img> with empty alt not marked decorative`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("Validate = %v, want %d errors", errs, len(want))
+	}
+	for i, err := range errs {
+		if !strings.HasPrefix(err.Error(), want[i]) {
+			t.Errorf("error %d = %q, want prefix %q", i, err, want[i])
+		}
+	}
+}
+
+func TestColorSchemeFromPrimary(t *testing.T) {
+	cs := gosite.ColorSchemeFromPrimary("#3F88BF")
+	if cs.Primary != "#3f88bf" {
+		t.Errorf("Primary = %s, want the input normalized", cs.Primary)
+	}
+	if cs.Secondary != "#bf763f" {
+		t.Errorf("Secondary = %s, want the complementary #bf763f", cs.Secondary)
+	}
+	if cs.Heading >= cs.Primary || cs.Border <= cs.Primary {
+		t.Errorf("Heading %s should be darker and Border %s lighter than %s", cs.Heading, cs.Border, cs.Primary)
+	}
+	if short := gosite.ColorSchemeFromPrimary("#38b"); short.Primary != "#3388bb" {
+		t.Errorf("short hex Primary = %s", short.Primary)
+	}
+	if def := gosite.ColorSchemeFromPrimary("blue"); *def != *gosite.DefaultColorScheme() {
+		t.Errorf("invalid color: got %+v, want the default scheme", def)
+	}
+
+	dark := cs.WithDark()
+	if dark.Background >= "#333333" || dark.Text <= "#cccccc" {
+		t.Errorf("dark variant has background %s and text %s", dark.Background, dark.Text)
+	}
+	if cs.Background != "#ffffff" {
+		t.Error("WithDark modified the original scheme")
+	}
+
+	site, files := newMemorySite(&gosite.Config{Title: "Palette", ColorScheme: cs})
+	site.NewPage("Home", "index.html").NewSection("Cards").Add(&card.Card{Title: "A"})
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if !strings.Contains(files["out/style.css"], "--color-heading: "+cs.Heading+";") {
+		t.Error("derived heading color not used in the base CSS")
+	}
+}
+
+func TestUtilityClassesDefined(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Utilities"})
+	site.NewPage("Home", "index.html").NewSection("").Add(&hero.Hero{Title: "Welcome"})
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	css := files["out/style.css"]
+	for _, class := range []string{".container {", ".bg-blue {", ".text-white {", ".grid {", ".btn {", ".text-md {"} {
+		if !strings.Contains(css, class) {
+			t.Errorf("style.css missing utility %q", class)
+		}
+	}
+	if strings.Index(css, ".bg-blue {") > strings.Index(css, "/* Component: Hero */") {
+		t.Error("utilities must precede component CSS so components can override them")
+	}
+}
+
+func TestLayoutConfig(t *testing.T) {
+	for _, tc := range []struct {
+		layout gosite.Layout
+		want   []string
+	}{
+		{gosite.Layout{}, []string{"--layout-max-width: 1200px;", "--section-padding: 2rem;"}},
+		{gosite.Layout{MaxWidth: "none", SectionPadding: "1rem 0"}, []string{"--layout-max-width: none;", "--section-padding: 1rem 0;"}},
+	} {
+		site, files := newMemorySite(&gosite.Config{Title: "Layout", Layout: tc.layout})
+		site.NewPage("Home", "index.html").NewSection("Cards").Add(&card.Card{Title: "A"})
+		if err := site.Generate(); err != nil {
+			t.Fatalf("Generate: %v", err)
+		}
+		css := files["out/style.css"]
+		for _, want := range append(tc.want, ".container { width: 100%; max-width: var(--layout-max-width);") {
+			if !strings.Contains(css, want) {
+				t.Errorf("Layout %+v: style.css missing %q", tc.layout, want)
+			}
+		}
+	}
+}
+
+func TestBreakpoints(t *testing.T) {
+	build := func(bp gosite.Breakpoints) string {
+		site, files := newMemorySite(&gosite.Config{Title: "Breakpoints", Breakpoints: bp})
+		site.NewPage("Home", "index.html").NewSection("").Add(&hero.Hero{Title: "Welcome"})
+		site.NewPage("About", "about.html") // adds the navbar and its max-width query
+		if err := site.Generate(); err != nil {
+			t.Fatalf("Generate: %v", err)
+		}
+		return files["out/style.css"]
+	}
+
+	css := build(gosite.Breakpoints{})
+	if !strings.Contains(css, "(max-width: 768px)") || !strings.Contains(css, "(min-width: 992px)") {
+		t.Error("default breakpoints changed")
+	}
+
+	css = build(gosite.Breakpoints{Mobile: "600px", Tablet: "768px"})
+	if strings.Contains(css, "992px") || strings.Contains(css, "(max-width: 768px)") {
+		t.Error("default breakpoints left in the CSS")
+	}
+	if !strings.Contains(css, "(max-width: 600px)") || !strings.Contains(css, "(min-width: 600px)") || !strings.Contains(css, "(min-width: 768px)") {
+		t.Error("configured breakpoints missing (Tablet must not be rewritten again to Mobile)")
+	}
+}
+
+func TestAutoprefix(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{".a {\n  user-select: none;\n}", ".a {\n  -webkit-user-select: none;\n  -moz-user-select: none;\n  user-select: none;\n}"},
+		{".b{position:sticky;top:0}", ".b{position:-webkit-sticky;position:sticky;top:0}"},
+		{".c { position: sticky !important }", ".c { position: -webkit-sticky !important; position: sticky !important }"},
+		{"@media (min-width: 900px) { .d { appearance: none } }", "@media (min-width: 900px) { .d { -webkit-appearance: none; -moz-appearance: none; appearance: none } }"},
+		// Left alone: selectors, strings, url(), custom and prefixed properties, other values
+		{".user-select:hover { color: red; }", ".user-select:hover { color: red; }"},
+		{`.e::after { content: "user-select: none;"; }`, `.e::after { content: "user-select: none;"; }`},
+		{".f { background: url(data:image/svg+xml;user-select:none) }", ".f { background: url(data:image/svg+xml;user-select:none) }"},
+		{".g { --user-select: none; -webkit-appearance: none; position: relative; }", ".g { --user-select: none; -webkit-appearance: none; position: relative; }"},
+		{"/* user-select: none; */ .h { color: blue }", "/* user-select: none; */ .h { color: blue }"},
+	}
+	for _, tc := range cases {
+		site, files := newMemorySite(&gosite.Config{Title: "Prefix", Autoprefix: true})
+		site.NewPage("Home", "index.html")
+		site.AddCSS(tc.in)
+		if err := site.Generate(); err != nil {
+			t.Fatalf("Generate: %v", err)
+		}
+		if css := files["out/style.css"]; !strings.Contains(css, tc.want) {
+			t.Errorf("autoprefix(%q): want %q in the output", tc.in, tc.want)
+		}
+	}
+
+	site, files := newMemorySite(&gosite.Config{Title: "Prefix"})
+	site.NewPage("Home", "index.html")
+	site.AddCSS(".a { user-select: none; }")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if strings.Contains(files["out/style.css"], "-moz-user-select") {
+		t.Error("prefixes added with Autoprefix off")
+	}
+}
+
+func TestScrollReveal(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Reveal", ScrollReveal: true})
+	site.NewPage("Home", "index.html").NewSection("").
+		Add(&card.Card{Title: "A", Reveal: true}).
+		Add(&servicecard.ServiceCard{Title: "B"})
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	if html := files["out/index.html"]; strings.Count(html, "data-reveal") != 1 || !strings.Contains(html, `<div class="card" data-reveal>`) {
+		t.Errorf("want only the card marked data-reveal:\n%s", html)
+	}
+	if !strings.Contains(files["out/style.css"], ".reveal-ready [data-reveal].revealed") {
+		t.Error("reveal CSS missing")
+	}
+	js := files["out/script.js"]
+	if !strings.Contains(js, "classList.add('revealed')") || !strings.Contains(js, "prefers-reduced-motion: reduce") {
+		t.Error("reveal script missing or ignores reduced motion")
+	}
+
+	site, files = newMemorySite(&gosite.Config{Title: "Reveal"})
+	site.NewPage("Home", "index.html").NewSection("").Add(&card.Card{Title: "A", Reveal: true})
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if strings.Contains(files["out/style.css"], "reveal-ready") || strings.Contains(files["out/script.js"], "Scroll reveal") {
+		t.Error("reveal assets emitted with ScrollReveal off")
+	}
+}
+
+func TestGlobalAssetsComeLast(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Global"})
+	site.AddGlobalCSS(".card { color: red; }")
+	site.AddGlobalCSS(".card { color: red; }")
+	site.AddGlobalJS("console.log('global');")
+	site.NewPage("Home", "index.html").NewSection("").Add(&card.Card{Title: "A"})
+	site.NewPage("About", "about.html").NewSection("")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	css := files["out/style.css"]
+	global := strings.Index(css, ".card { color: red; }")
+	if global < 0 || strings.Count(css, ".card { color: red; }") != 1 {
+		t.Fatalf("want the global CSS once:\n%s", css)
+	}
+	if global < strings.Index(css, "/* Component: Card */") || global < strings.Index(css, ".main-nav") {
+		t.Errorf("global CSS written before component or nav CSS:\n%s", css)
+	}
+	js := files["out/script.js"]
+	if !strings.HasSuffix(strings.TrimSpace(js), "console.log('global');") {
+		t.Errorf("global JS is not the last script:\n%s", js)
+	}
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	build := func() *gosite.Site {
+		site := gosite.New(&gosite.Config{Title: "Stable", ScrollReveal: true, Autoprefix: true})
+		site.AddGlobalCSS(".x { color: red; }")
+		site.NewPage("Home", "index.html").NewSection("Home").
+			Add(&themeoverride.ThemeOverride{
+				Colors:   map[string]string{"primary": "#111", "secondary": "#222", "accent": "#333", "--custom": "#444"},
+				Children: []themeoverride.HTMLRenderer{&card.Card{Title: "A", Reveal: true}},
+			}).
+			Add(&carousel.Carousel{}).
+			Add(&backtotop.BackToTop{})
+		site.NewPage("About", "about.html").NewSection("About").
+			Add(&servicecard.ServiceCard{Title: "B"}).
+			Add(&card.Card{Title: "C"})
+		return site
+	}
+
+	site := build()
+	first, err := site.GenerateToMap()
+	if err != nil {
+		t.Fatalf("GenerateToMap: %v", err)
+	}
+	second, err := site.GenerateToMap()
+	if err != nil {
+		t.Fatalf("GenerateToMap: %v", err)
+	}
+	fresh, err := build().GenerateToMap()
+	if err != nil {
+		t.Fatalf("GenerateToMap: %v", err)
+	}
+
+	for name, want := range first {
+		if second[name] != want {
+			t.Errorf("%s differs between two builds of the same site", name)
+		}
+		if fresh[name] != want {
+			t.Errorf("%s differs between two identical sites", name)
+		}
+	}
+	if len(second) != len(first) || len(fresh) != len(first) {
+		t.Errorf("file sets differ: %d, %d, %d", len(first), len(second), len(fresh))
+	}
+}
+
+func TestHandler(t *testing.T) {
+	builds := 0
+	site := gosite.New(&gosite.Config{Title: "Preview", Precompress: []string{gosite.PrecompressGzip}})
+	handler := site.Handler(func(s *gosite.Site) {
+		builds++
+		s.NewPage("Home", "index.html").NewSection("Build " + strconv.Itoa(builds)).Add(&card.Card{Title: "A"})
+		s.NewPage("About", "about.html")
+		s.AddIcon("dot", `<circle cx="12" cy="12" r="4"/>`)
+	})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		return rec
+	}
+
+	for _, tc := range []struct {
+		path, contentType, body string
+	}{
+		{"/", "text/html; charset=utf-8", "<h1>Build 1</h1>"},
+		{"/about.html", "text/html; charset=utf-8", "<title>About</title>"},
+		{"/style.css", "text/css; charset=utf-8", ".card {"},
+		{"/icons.svg", "image/svg+xml", `<symbol id="dot"`},
+		{"/style.css.gz", "application/gzip", "\x1f\x8b"},
+	} {
+		rec := get(tc.path)
+		if rec.Code != 200 {
+			t.Errorf("GET %s: status %d", tc.path, rec.Code)
+			continue
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != tc.contentType {
+			t.Errorf("GET %s: Content-Type %q, want %q", tc.path, ct, tc.contentType)
+		}
+		if !strings.Contains(rec.Body.String(), tc.body) {
+			t.Errorf("GET %s: body missing %s", tc.path, tc.body)
+		}
+	}
+
+	if rec := get("/missing.html"); rec.Code != 404 {
+		t.Errorf("GET /missing.html: status %d, want 404", rec.Code)
+	}
+	if builds != 6 {
+		t.Errorf("rebuild ran %d times, want once per request", builds)
+	}
+	if body := get("/index.html").Body.String(); !strings.Contains(body, "<h1>Build 7</h1>") || site.PageCount() != 2 {
+		t.Errorf("rebuild did not replace the pages (%d pages):\n%s", site.PageCount(), body)
+	}
+}
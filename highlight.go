@@ -0,0 +1,24 @@
+package gosite
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// highlight.js files loaded by Config.SyntaxHighlight.
+const (
+	highlightJS  = "https://cdnjs.cloudflare.com/ajax/libs/highlight.js/11.9.0/highlight.min.js"
+	highlightCSS = "https://cdnjs.cloudflare.com/ajax/libs/highlight.js/11.9.0/styles/github.min.css"
+)
+
+// renderHighlightHead returns the highlight.js stylesheet and scripts for the
+// page head, or "" when Config.SyntaxHighlight is off. highlight.js colors
+// every <pre><code class="language-x"> except those marked "nohighlight".
+func renderHighlightHead(cfg *Config) string {
+	if !cfg.SyntaxHighlight {
+		return ""
+	}
+	nonce := scriptNonce(cfg)
+	return Fmt("  <link rel=\"stylesheet\" href=\"%s\" crossorigin=\"anonymous\" referrerpolicy=\"no-referrer\">\n", highlightCSS) +
+		Fmt("  <script%s defer src=\"%s\" crossorigin=\"anonymous\" referrerpolicy=\"no-referrer\"></script>\n", nonce, highlightJS) +
+		Fmt("  <script%s>document.addEventListener('DOMContentLoaded', function() { if (window.hljs) hljs.highlightAll(); });</script>\n", nonce)
+}
@@ -0,0 +1,240 @@
+package gosite
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// rawElements keep their content verbatim: whitespace is significant in
+// pre/textarea and script/style bodies are not markup.
+var rawElements = map[string]bool{
+	"pre": true, "textarea": true, "script": true, "style": true,
+}
+
+// voidElements never have a closing tag, so they don't open a nesting level.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true,
+	"img": true, "input": true, "link": true, "meta": true, "param": true,
+	"source": true, "track": true, "wbr": true,
+}
+
+// blockElements are the elements whose surrounding whitespace never renders,
+// so minified output may drop it. Whitespace next to any other element
+// (a, b, em, span, button...) separates words and is kept as one space.
+var blockElements = map[string]bool{
+	"!doctype": true, "!--": true, "html": true, "head": true, "body": true,
+	"title": true, "meta": true, "link": true, "script": true, "style": true, "noscript": true,
+	"main": true, "header": true, "footer": true, "nav": true, "section": true, "article": true,
+	"aside": true, "div": true, "p": true, "h1": true, "h2": true, "h3": true, "h4": true,
+	"h5": true, "h6": true, "ul": true, "ol": true, "li": true, "dl": true, "dt": true, "dd": true,
+	"table": true, "thead": true, "tbody": true, "tfoot": true, "tr": true, "th": true, "td": true,
+	"form": true, "fieldset": true, "figure": true, "figcaption": true, "blockquote": true,
+	"pre": true, "hr": true, "details": true, "summary": true, "address": true, "template": true,
+	"option": true,
+}
+
+// htmlToken is a tag, a text run or a verbatim raw element block.
+type htmlToken struct {
+	text    string
+	name    string // lower-case tag name, empty for text
+	closing bool
+	void    bool // void element, self-closing tag, comment or doctype
+	raw     bool // complete raw element including its content
+}
+
+// formatHTML post-processes a rendered page. When pretty is false every
+// whitespace run is collapsed to a single space, and whitespace between two
+// block-level tags is dropped, to reduce payload size; a space between
+// inline elements still renders, so it is kept. When pretty is true the
+// markup is re-indented two spaces per nesting level, keeping elements that
+// only wrap text on a single line.
+// Content of pre, textarea, script and style elements is never modified.
+func formatHTML(html string, pretty bool) string {
+	tokens := tokenizeHTML(html)
+	b := Convert()
+
+	if !pretty {
+		for i, t := range tokens {
+			switch {
+			case t.name != "":
+				b.Write(t.text)
+			case collapseSpaces(t.text) != "":
+				b.Write(collapseSpaces(t.text))
+			case !isBlockToken(tokens, i-1) || !isBlockToken(tokens, i+1):
+				b.Write(" ")
+			}
+		}
+		return b.String()
+	}
+
+	depth := 0
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		switch {
+		case t.name == "" && collapseSpaces(t.text) == "":
+			// Indentation already separates the lines.
+			continue
+		case t.name == "":
+			b.Write(indent(depth))
+			b.Write(Convert(collapseSpaces(t.text)).TrimSpace().String())
+		case t.closing:
+			if depth > 0 {
+				depth--
+			}
+			b.Write(indent(depth))
+			b.Write(t.text)
+		case t.void || t.raw:
+			b.Write(indent(depth))
+			b.Write(t.text)
+		default:
+			b.Write(indent(depth))
+			b.Write(t.text)
+			// Keep <tag>text</tag> and <tag></tag> on one line.
+			if i+1 < len(tokens) && isClosingOf(tokens[i+1], t.name) {
+				b.Write(tokens[i+1].text)
+				i++
+			} else if i+2 < len(tokens) && tokens[i+1].name == "" && collapseSpaces(tokens[i+1].text) != "" && isClosingOf(tokens[i+2], t.name) {
+				b.Write(Convert(collapseSpaces(tokens[i+1].text)).TrimSpace().String())
+				b.Write(tokens[i+2].text)
+				i += 2
+			} else {
+				depth++
+			}
+		}
+		b.Write("\n")
+	}
+	return b.String()
+}
+
+// tokenizeHTML splits html into tags and text runs; a whitespace-only run
+// between two tags is kept as its own text token.
+func tokenizeHTML(html string) []htmlToken {
+	var tokens []htmlToken
+	i := 0
+	for i < len(html) {
+		if html[i] != '<' {
+			end := Index(html[i:], "<")
+			if end < 0 {
+				end = len(html) - i
+			}
+			tokens = append(tokens, htmlToken{text: html[i : i+end]})
+			i += end
+			continue
+		}
+
+		if HasPrefix(html[i:], "<!--") {
+			end := Index(html[i:], "-->")
+			if end < 0 {
+				end = len(html) - i - 3
+			}
+			tokens = append(tokens, htmlToken{text: html[i : i+end+3], name: "!--", void: true})
+			i += end + 3
+			continue
+		}
+
+		end := Index(html[i:], ">")
+		if end < 0 {
+			tokens = append(tokens, htmlToken{text: html[i:]})
+			break
+		}
+		tag := html[i : i+end+1]
+		i += end + 1
+
+		t := htmlToken{text: tag, name: tagName(tag)}
+		t.closing = HasPrefix(tag, "</")
+		t.void = voidElements[t.name] || HasSuffix(tag, "/>") || HasPrefix(tag, "<!")
+
+		if rawElements[t.name] && !t.closing && !t.void {
+			if close := indexClosingTag(html[i:], t.name); close >= 0 {
+				closeEnd := Index(html[i+close:], ">")
+				t.text = html[i-len(tag) : i+close+closeEnd+1]
+				i += close + closeEnd + 1
+			} else {
+				t.text = html[i-len(tag):]
+				i = len(html)
+			}
+			t.raw = true
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens
+}
+
+// tagName returns the lower-case element name of a tag like <div class="x">.
+func tagName(tag string) string {
+	start := 1
+	if len(tag) > 1 && tag[1] == '/' {
+		start = 2
+	}
+	end := start
+	for end < len(tag) {
+		c := tag[end]
+		if c == ' ' || c == '>' || c == '/' || c == '\t' || c == '\n' || c == '\r' {
+			break
+		}
+		end++
+	}
+	return asciiLower(tag[start:end])
+}
+
+// indexClosingTag finds "</name" in s, ignoring ASCII case.
+func indexClosingTag(s, name string) int {
+	for i := 0; i+2+len(name) <= len(s); i++ {
+		if s[i] == '<' && s[i+1] == '/' && asciiLower(s[i+2:i+2+len(name)]) == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// isBlockToken reports whether tokens[i] is a block-level tag; the start and
+// end of the document count as block boundaries.
+func isBlockToken(tokens []htmlToken, i int) bool {
+	if i < 0 || i >= len(tokens) {
+		return true
+	}
+	return tokens[i].name != "" && blockElements[tokens[i].name]
+}
+
+func isClosingOf(t htmlToken, name string) bool {
+	return t.closing && t.name == name
+}
+
+func asciiLower(s string) string {
+	out := []byte(s)
+	for i, c := range out {
+		if c >= 'A' && c <= 'Z' {
+			out[i] = c + ('a' - 'A')
+		}
+	}
+	return string(out)
+}
+
+// collapseSpaces replaces every whitespace run with a single space.
+// Whitespace-only input collapses to the empty string.
+func collapseSpaces(s string) string {
+	out := make([]byte, 0, len(s))
+	space, content := false, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == ' ' || c == '\n' || c == '\t' || c == '\r' {
+			space = true
+			continue
+		}
+		if space {
+			out = append(out, ' ')
+		}
+		space, content = false, true
+		out = append(out, c)
+	}
+	if !content {
+		return ""
+	}
+	if space {
+		out = append(out, ' ')
+	}
+	return string(out)
+}
+
+func indent(depth int) string {
+	return Convert("  ").Repeat(depth).String()
+}
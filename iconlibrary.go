@@ -0,0 +1,72 @@
+package gosite
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// Icon libraries for Config.IconLibrary.
+const (
+	IconsInline      = "inline"      // replace known <i class="fa-..."> icons with bundled SVGs (default)
+	IconsFontAwesome = "fontawesome" // link the Font Awesome stylesheet from every page
+	IconsNone        = "none"        // leave <i> icons as they are; the site provides the icon CSS
+)
+
+// fontAwesomeCSS is the stylesheet linked by IconsFontAwesome.
+const fontAwesomeCSS = "https://cdnjs.cloudflare.com/ajax/libs/font-awesome/6.5.1/css/all.min.css"
+
+// inlineIcons maps Font Awesome icon names to the inner markup of a 24x24 SVG.
+var inlineIcons = map[string]string{
+	"fa-arrow-right":    `<path d="M12 4l-1.41 1.41L16.17 11H4v2h12.17l-5.58 5.59L12 20l8-8z"/>`,
+	"fa-check":          `<path d="M9 16.17 4.83 12l-1.42 1.41L9 19 21 7l-1.41-1.41z"/>`,
+	"fa-clock":          `<path d="M11.99 2C6.47 2 2 6.48 2 12s4.47 10 9.99 10C17.52 22 22 17.52 22 12S17.52 2 11.99 2zM12 20c-4.42 0-8-3.58-8-8s3.58-8 8-8 8 3.58 8 8-3.58 8-8 8zm.5-13H11v6l5.25 3.15.75-1.23-4.5-2.67z"/>`,
+	"fa-comment":        `<path d="M20 2H4c-1.1 0-2 .9-2 2v18l4-4h14c1.1 0 2-.9 2-2V4c0-1.1-.9-2-2-2z"/>`,
+	"fa-envelope":       `<path d="M20 4H4c-1.1 0-1.99.9-1.99 2L2 18c0 1.1.9 2 2 2h16c1.1 0 2-.9 2-2V6c0-1.1-.9-2-2-2zm0 4l-8 5-8-5V6l8 5 8-5v2z"/>`,
+	"fa-facebook-f":     `<path d="M14 13.5h2.5l1-4H14v-2c0-1.03 0-2 2-2h1.5V2.14C17.17 2.1 15.95 2 14.64 2 11.93 2 10 3.66 10 6.7v2.8H7v4h3V22h4v-8.5z"/>`,
+	"fa-heart":          `<path d="M12 21.35l-1.45-1.32C5.4 15.36 2 12.28 2 8.5 2 5.42 4.42 3 7.5 3c1.74 0 3.41.81 4.5 2.09C13.09 3.81 14.76 3 16.5 3 19.58 3 22 5.42 22 8.5c0 3.78-3.4 6.86-8.55 11.54L12 21.35z"/>`,
+	"fa-map-marker-alt": `<path d="M12 2C8.13 2 5 5.13 5 9c0 5.25 7 13 7 13s7-7.75 7-13c0-3.87-3.13-7-7-7zm0 9.5c-1.38 0-2.5-1.12-2.5-2.5s1.12-2.5 2.5-2.5 2.5 1.12 2.5 2.5-1.12 2.5-2.5 2.5z"/>`,
+	"fa-phone":          `<path d="M6.62 10.79c1.44 2.83 3.76 5.14 6.59 6.59l2.2-2.2c.27-.27.67-.36 1.02-.24 1.12.37 2.33.57 3.57.57.55 0 1 .45 1 1V20c0 .55-.45 1-1 1-9.39 0-17-7.61-17-17 0-.55.45-1 1-1h3.5c.55 0 1 .45 1 1 0 1.25.2 2.45.57 3.57.11.35.03.74-.25 1.02l-2.2 2.2z"/>`,
+	"fa-quote-left":     `<path transform="rotate(180 12 12)" d="M6 17h3l2-4V7H5v6h3zm8 0h3l2-4V7h-6v6h3z"/>`,
+	"fa-quote-right":    `<path d="M6 17h3l2-4V7H5v6h3zm8 0h3l2-4V7h-6v6h3z"/>`,
+	"fa-star":           `<path d="M12 17.27 18.18 21l-1.64-7.03L22 9.24l-7.19-.61L12 2 9.19 8.63 2 9.24l5.46 4.73L5.82 21z"/>`,
+	"fa-user":           `<path d="M12 12c2.21 0 4-1.79 4-4s-1.79-4-4-4-4 1.79-4 4 1.79 4 4 4zm0 2c-2.67 0-8 1.34-8 4v2h16v-2c0-2.66-5.33-4-8-4z"/>`,
+}
+
+// inlineIconTags replaces empty <i class="... fa-name"></i> elements whose
+// icon is in inlineIcons with an equivalent SVG sized to the text. Other <i>
+// elements are left untouched.
+func inlineIconTags(html string) string {
+	const open, close = `<i class="`, `"></i>`
+	if !Contains(html, open) {
+		return html
+	}
+
+	b := Convert()
+	for {
+		start := Index(html, open)
+		if start < 0 {
+			break
+		}
+		end := Index(html[start+len(open):], `"`)
+		if end < 0 || !HasPrefix(html[start+len(open)+end:], close) {
+			b.Write(html[:start+len(open)])
+			html = html[start+len(open):]
+			continue
+		}
+		class := html[start+len(open) : start+len(open)+end]
+		svg, ok := "", false
+		for _, name := range Convert(class).Split(" ") {
+			if svg, ok = inlineIcons[name]; ok {
+				break
+			}
+		}
+		if !ok {
+			b.Write(html[:start+len(open)+end+len(close)])
+		} else {
+			b.Write(html[:start])
+			b.Write(Fmt(`<svg class="icon-inline %s" viewBox="0 0 24 24" fill="currentColor" aria-hidden="true" focusable="false">%s</svg>`, class, svg))
+		}
+		html = html[start+len(open)+end+len(close):]
+	}
+	b.Write(html)
+	return b.String()
+}
@@ -2,10 +2,16 @@ package gosite
 
 // SiteLink defines the interface for communication between components and the site.
 type SiteLink interface {
+	Config() *Config
 	PageCount() int
 	BuildNav() string
 	AddCSS(css string)
+	AddCSSWithKey(key, css string)
 	AddJS(js string)
+	JSModules(p *Page) []JSModule
+	JSBlocks() []string
+	CriticalCSS() string
+	NewComponent(name string, props map[string]any) (HTMLRenderer, error)
 }
 
 // EventBinder adds or removes an event listener from a DOM element.
@@ -28,3 +34,39 @@ type CSSRenderer interface {
 type JSRenderer interface {
 	RenderJS() string
 }
+
+// EventRenderer is an optional interface for components that attach their
+// behavior through an EventBinder in the frontend (WASM) instead of emitting
+// script text. Components usually implement it in a wasm-only file and keep
+// JSRenderer for the static path.
+type EventRenderer interface {
+	BindEvents(b EventBinder)
+}
+
+// CSSKeyRenderer is an optional interface for CSS renderers that declare a
+// stable identity. Blocks sharing a key are deduplicated even when their
+// content differs; the first one added is kept.
+type CSSKeyRenderer interface {
+	CSSKey() string
+}
+
+// JSKeyRenderer is an optional interface for JS renderers that declare a
+// stable module name. With Config.ESModules the key names the module file and
+// its import map specifier, e.g. import "carousel".
+type JSKeyRenderer interface {
+	JSKey() string
+}
+
+// PrintCSSRenderer is an optional interface for components that contribute
+// print rules. They are wrapped in @media print and only collected when
+// Config.PrintStyles is enabled.
+type PrintCSSRenderer interface {
+	RenderPrintCSS() string
+}
+
+// ContainerRenderer is implemented by layout components that wrap other
+// components. When a container is added to a section, the CSS/JS of each
+// child is collected (and deduplicated) as if it had been added directly.
+type ContainerRenderer interface {
+	ChildComponents() []any
+}
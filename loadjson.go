@@ -0,0 +1,85 @@
+//go:build !wasm
+
+package gosite
+
+import (
+	"encoding/json"
+
+	. "github.com/cdvelop/tinystring"
+)
+
+// jsonSite is the document accepted by LoadFromJSON.
+type jsonSite struct {
+	Pages []jsonPage `json:"pages"`
+}
+
+type jsonPage struct {
+	Title    string        `json:"title"`
+	Filename string        `json:"filename"` // Optional: derived from the title when empty
+	Head     []string      `json:"head"`
+	Sections []jsonSection `json:"sections"`
+}
+
+type jsonSection struct {
+	Title      string          `json:"title"`
+	ID         string          `json:"id"`
+	Class      string          `json:"class"`
+	Components []jsonComponent `json:"components"`
+}
+
+type jsonComponent struct {
+	Name  string         `json:"name"`
+	Props map[string]any `json:"props"`
+}
+
+// LoadFromJSON builds pages from a JSON document, creating components through
+// the registry (see RegisterComponent):
+//
+//	{"pages": [{"title": "Home", "filename": "index.html", "sections": [
+//	    {"title": "Services", "components": [{"name": "card", "props": {"title": "Web"}}]}
+//	]}]}
+//
+// The whole document is validated before any page is added; errors name the
+// offending path, e.g. "pages[0].sections[1].components[2].name: ...".
+func (s *Site) LoadFromJSON(data []byte) error {
+	var doc jsonSite
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return Err("json:", err.Error())
+	}
+	if len(doc.Pages) == 0 {
+		return Err("pages", D.Required)
+	}
+
+	for i, p := range doc.Pages {
+		if p.Title == "" {
+			return Err(Fmt("pages[%d].title:", i), D.Required)
+		}
+		for j, sec := range p.Sections {
+			for k, c := range sec.Components {
+				path := Fmt("pages[%d].sections[%d].components[%d].name:", i, j, k)
+				if c.Name == "" {
+					return Err(path, D.Required)
+				}
+				if _, ok := s.registry[c.Name]; !ok {
+					return Err(path, "component", c.Name, D.Not, D.Found)
+				}
+			}
+		}
+	}
+
+	for _, p := range doc.Pages {
+		page := s.NewPage(p.Title, p.Filename)
+		for _, h := range p.Head {
+			page.AddHead(h)
+		}
+		for _, sec := range p.Sections {
+			section := page.NewSection(sec.Title).SetID(sec.ID).SetClass(sec.Class)
+			for _, c := range sec.Components {
+				if err := section.AddByName(c.Name, c.Props); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+package gosite
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// MarkdownToHTML converts a CommonMark subset to HTML: ATX headings,
+// paragraphs, fenced code blocks, blockquotes, ordered/unordered lists,
+// horizontal rules, and inline code, links, images, strong and emphasis.
+// Raw HTML in the source is escaped, never passed through, and javascript:,
+// vbscript: and data: URLs are not emitted as link or image targets.
+func MarkdownToHTML(md string) string {
+	lines := Convert(Convert(md).Replace("\r\n", "\n").String()).Split("\n")
+	b := Convert()
+
+	var paragraph []string
+	listTag := "" // "ul" or "ol" while a list is open
+
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			b.Write("<p>" + renderInline(Convert(paragraph).Join(" ").String()) + "</p>\n")
+			paragraph = nil
+		}
+	}
+	closeList := func() {
+		if listTag != "" {
+			b.Write("</" + listTag + ">\n")
+			listTag = ""
+		}
+	}
+	openList := func(tag string) {
+		if listTag != tag {
+			closeList()
+			b.Write("<" + tag + ">\n")
+			listTag = tag
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := Convert(line).TrimSpace().String()
+
+		switch {
+		case HasPrefix(trimmed, "```"):
+			flushParagraph()
+			closeList()
+			lang := Convert(trimmed[3:]).TrimSpace().String()
+			var code []string
+			for i++; i < len(lines) && !HasPrefix(Convert(lines[i]).TrimSpace().String(), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			class := ""
+			if lang != "" {
+				class = Fmt(" class=\"language-%s\"", Convert(lang).EscapeAttr())
+			}
+			b.Write(Fmt("<pre><code%s>%s</code></pre>\n", class, Convert(Convert(code).Join("\n").String()).EscapeHTML()))
+
+		case trimmed == "":
+			flushParagraph()
+			closeList()
+
+		case headingLevel(trimmed) > 0:
+			flushParagraph()
+			closeList()
+			level := headingLevel(trimmed)
+			text := Convert(trimmed[level:]).TrimSpace().String()
+			b.Write(Fmt("<h%d>%s</h%d>\n", level, renderInline(text), level))
+
+		case trimmed == "---" || trimmed == "***" || trimmed == "___":
+			flushParagraph()
+			closeList()
+			b.Write("<hr>\n")
+
+		case HasPrefix(trimmed, ">"):
+			flushParagraph()
+			closeList()
+			var quote []string
+			for ; i < len(lines); i++ {
+				q := Convert(lines[i]).TrimSpace().String()
+				if !HasPrefix(q, ">") {
+					i--
+					break
+				}
+				quote = append(quote, Convert(q[1:]).TrimPrefix(" ").String())
+			}
+			b.Write("<blockquote>\n" + MarkdownToHTML(Convert(quote).Join("\n").String()) + "</blockquote>\n")
+
+		case HasPrefix(trimmed, "- ") || HasPrefix(trimmed, "* ") || HasPrefix(trimmed, "+ "):
+			flushParagraph()
+			openList("ul")
+			b.Write("<li>" + renderInline(Convert(trimmed[2:]).TrimSpace().String()) + "</li>\n")
+
+		case orderedItem(trimmed) > 0:
+			flushParagraph()
+			openList("ol")
+			b.Write("<li>" + renderInline(Convert(trimmed[orderedItem(trimmed):]).TrimSpace().String()) + "</li>\n")
+
+		default:
+			closeList()
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flushParagraph()
+	closeList()
+	return b.String()
+}
+
+// headingLevel returns 1-6 for an ATX heading line like "## Title", else 0.
+func headingLevel(line string) int {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || level == len(line) || line[level] != ' ' {
+		return 0
+	}
+	return level
+}
+
+// orderedItem returns the length of an ordered list marker like "12. ", else 0.
+func orderedItem(line string) int {
+	n := 0
+	for n < len(line) && line[n] >= '0' && line[n] <= '9' {
+		n++
+	}
+	if n == 0 || n+1 >= len(line) || line[n] != '.' || line[n+1] != ' ' {
+		return 0
+	}
+	return n + 2
+}
+
+// renderInline converts inline Markdown to HTML, escaping everything else.
+func renderInline(s string) string {
+	b := Convert()
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		rest := s[i:]
+
+		switch {
+		case c == '`':
+			if end := Index(s[i+1:], "`"); end >= 0 {
+				b.Write("<code>" + Convert(s[i+1:i+1+end]).EscapeHTML() + "</code>")
+				i += end + 1
+				continue
+			}
+
+		case c == '!' && HasPrefix(rest, "!["):
+			if text, url, n := parseLink(rest[1:]); n > 0 {
+				if unsafeURL(url) {
+					b.Write(Convert(text).EscapeHTML()) // keep the alt text only
+				} else {
+					b.Write(Fmt("<img src=\"%s\" alt=\"%s\">", Convert(url).EscapeAttr(), Convert(text).EscapeAttr()))
+				}
+				i += n
+				continue
+			}
+
+		case c == '[':
+			if text, url, n := parseLink(rest); n > 0 {
+				if unsafeURL(url) {
+					url = "#"
+				}
+				b.Write(Fmt("<a href=\"%s\">%s</a>", Convert(url).EscapeAttr(), renderInline(text)))
+				i += n - 1
+				continue
+			}
+
+		case HasPrefix(rest, "**") || HasPrefix(rest, "__"):
+			if end := Index(s[i+2:], rest[:2]); end > 0 {
+				b.Write("<strong>" + renderInline(s[i+2:i+2+end]) + "</strong>")
+				i += end + 3
+				continue
+			}
+
+		case c == '*' || (c == '_' && (i == 0 || !isWordByte(s[i-1]))):
+			if end := Index(s[i+1:], string(c)); end > 0 {
+				b.Write("<em>" + renderInline(s[i+1:i+1+end]) + "</em>")
+				i += end + 1
+				continue
+			}
+		}
+		b.Write(escapeByte(c))
+	}
+	return b.String()
+}
+
+// parseLink parses "[text](url)" at the start of s and returns its parts and
+// total length, or n == 0 when s does not start with a link. Parentheses
+// inside the URL are balanced, so "(a_(b))" keeps "a_(b)" as the URL.
+func parseLink(s string) (text, url string, n int) {
+	mid := Index(s, "](")
+	if mid < 1 {
+		return "", "", 0
+	}
+	depth := 0
+	for i := mid + 2; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				return s[1:mid], s[mid+2 : i], i + 1
+			}
+			depth--
+		}
+	}
+	return "", "", 0
+}
+
+// unsafeURL reports whether url uses a scheme that runs code or embeds
+// content: javascript:, vbscript: or data:. Browsers ignore ASCII control
+// characters and spaces in the scheme, so they are skipped before matching.
+func unsafeURL(url string) bool {
+	colon := Index(url, ":")
+	if colon < 0 {
+		return false // no scheme: a relative URL
+	}
+	scheme := make([]byte, 0, colon)
+	for i := 0; i < colon; i++ {
+		if url[i] > ' ' {
+			scheme = append(scheme, url[i])
+		}
+	}
+	switch asciiLower(string(scheme)) {
+	case "javascript", "vbscript", "data":
+		return true
+	}
+	return false
+}
+
+// isWordByte reports whether c is an ASCII letter or digit, so that
+// snake_case words are not read as emphasis.
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func escapeByte(c byte) string {
+	switch c {
+	case '&':
+		return "&amp;"
+	case '<':
+		return "&lt;"
+	case '>':
+		return "&gt;"
+	case '"':
+		return "&quot;"
+	case '\'':
+		return "&#39;"
+	}
+	return string([]byte{c})
+}
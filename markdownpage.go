@@ -0,0 +1,69 @@
+//go:build !wasm
+
+package gosite
+
+import (
+	"os"
+
+	. "github.com/cdvelop/tinystring"
+)
+
+// AddMarkdownPage reads a Markdown file and registers a page with a single
+// section holding the rendered body (see MarkdownToHTML). The file may start
+// with a front matter block of `key: value` lines between "---" fences:
+//
+//	---
+//	title: About us
+//	filename: about.html
+//	description: Who we are
+//	---
+//
+// title is required; filename is derived from the title when empty and
+// description becomes the page meta description.
+func (s *Site) AddMarkdownPage(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Err("markdown:", err.Error())
+	}
+
+	meta, body := parseFrontMatter(string(data))
+	if meta["title"] == "" {
+		return Err(path, "title", D.Required)
+	}
+
+	page := s.NewPage(meta["title"], meta["filename"])
+	if desc := meta["description"]; desc != "" {
+		page.SetDescription(desc)
+	}
+	page.NewSection("").AddRaw(MarkdownToHTML(body))
+	return nil
+}
+
+// parseFrontMatter splits a leading "---" fenced block of `key: value` lines
+// from the body. Values may be wrapped in single or double quotes.
+func parseFrontMatter(src string) (map[string]string, string) {
+	meta := make(map[string]string)
+	src = Convert(src).Replace("\r\n", "\n").String()
+	if !HasPrefix(src, "---\n") {
+		return meta, src
+	}
+
+	lines := Convert(src[4:]).Split("\n")
+	for i, line := range lines {
+		if Convert(line).TrimSpace().String() == "---" {
+			return meta, Convert(lines[i+1:]).Join("\n").String()
+		}
+		colon := Index(line, ":")
+		if colon < 0 {
+			continue
+		}
+		key := Convert(line[:colon]).TrimSpace().ToLower().String()
+		value := Convert(line[colon+1:]).TrimSpace().String()
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		meta[key] = value
+	}
+	// Unterminated front matter: treat the whole file as body.
+	return make(map[string]string), src
+}
@@ -1,6 +1,7 @@
 package gosite
 
 import (
+	"github.com/cdvelop/gosite/components/i18n"
 	. "github.com/cdvelop/tinystring"
 )
 
@@ -15,14 +16,14 @@ func (n *NavbarBuilder) Render() string {
 
 	b.Write("<nav class=\"main-nav\">\n")
 	b.Write("  <input type=\"checkbox\" id=\"sidebar-active\">\n")
-	b.Write("  <label for=\"sidebar-active\" class=\"open-sidebar-button\">\n")
+	b.Write(Fmt("  <label for=\"sidebar-active\" class=\"open-sidebar-button\" aria-label=\"%s\">\n", Translate(i18n.D.OpenMenu).EscapeAttr()))
 	b.Write("    <svg xmlns=\"http://www.w3.org/2000/svg\" height=\"32\" viewBox=\"0 -960 960 960\" width=\"32\">\n")
 	b.Write("      <path d=\"M120-240v-80h720v80H120Zm0-200v-80h720v80H120Zm0-200v-80h720v80H120Z\"/>\n")
 	b.Write("    </svg>\n")
 	b.Write("  </label>\n")
 	b.Write("  <label id=\"overlay\" for=\"sidebar-active\"></label>\n")
 	b.Write("  <div class=\"links-container\">\n")
-	b.Write("    <label for=\"sidebar-active\" class=\"close-sidebar-button\">\n")
+	b.Write(Fmt("    <label for=\"sidebar-active\" class=\"close-sidebar-button\" aria-label=\"%s\">\n", Translate(i18n.D.CloseMenu).EscapeAttr()))
 	b.Write("      <svg xmlns=\"http://www.w3.org/2000/svg\" height=\"32\" viewBox=\"0 -960 960 960\" width=\"32\">\n")
 	b.Write("        <path d=\"m256-200-56-56 224-224-224-224 56-56 224 224 224-224 56 56-224 224 224 224-56 56-224-224-224 224Z\"/>\n")
 	b.Write("      </svg>\n")
@@ -47,10 +48,14 @@ func (n *NavbarBuilder) Render() string {
 	return b.String()
 }
 
-// RenderCSS generates the navbar CSS with responsive styles
+// RenderCSS generates the navbar CSS with responsive styles and the page
+// transition selected by Config.PageTransition.
 func (n *NavbarBuilder) RenderCSS() string {
-	//*css
-	return `/* Main nav styles */
+	return navCSS + transitionCSS(n.site.Cfg.PageTransition)
+}
+
+// navCSS holds the nav bar styles.
+const navCSS = `/* Main nav styles */
 .main-nav {
 	height: 60px;
 	background: linear-gradient(135deg, var(--color-primary), #2c6aa0);
@@ -93,6 +98,17 @@ func (n *NavbarBuilder) RenderCSS() string {
 	font-weight: 600;
 }
 
+/* Link of the section in view, set by the scroll spy */
+.main-nav a.active {
+	background: rgba(255,255,255,0.2);
+	box-shadow: inset 0 -3px 0 white;
+}
+
+.nav-link.active {
+	opacity: 1;
+	color: var(--fros-blue-color, var(--color-primary));
+}
+
 /* SVG styles */
 .main-nav svg {
 	fill: white;
@@ -158,16 +174,16 @@ func (n *NavbarBuilder) RenderCSS() string {
 		background: rgba(0,0,0,0.5);
 	}
 }
+`
 
-/* View Transition API */
-@view-transition {
-	navigation: auto;
-}
-
-::view-transition-group(*) {
-	animation-duration: 0.3s;
-}
+// transitionCSS returns the View Transition rules for mode; unknown modes
+// fade.
+func transitionCSS(mode string) string {
+	if mode == TransitionNone {
+		return ""
+	}
 
+	keyframes := `
 /* Smooth fade transition for page content */
 @keyframes fade-in {
 	from {
@@ -189,10 +205,52 @@ func (n *NavbarBuilder) RenderCSS() string {
 	animation: 300ms cubic-bezier(0, 0, 0.2, 1) both fade-in;
 }
 `
+	if mode == TransitionSlide {
+		keyframes = `
+/* Slide transition for page content */
+@keyframes slide-in {
+	from {
+		opacity: 0;
+		transform: translateX(40px);
+	}
+}
+
+@keyframes slide-out {
+	to {
+		opacity: 0;
+		transform: translateX(-40px);
+	}
+}
+
+::view-transition-old(root) {
+	animation: 200ms cubic-bezier(0.4, 0, 1, 1) both slide-out;
+}
+
+::view-transition-new(root) {
+	animation: 300ms cubic-bezier(0, 0, 0.2, 1) both slide-in;
+}
+`
+	}
+
+	return `
+/* View Transition API */
+@view-transition {
+	navigation: auto;
 }
 
-// RenderJS generates the JavaScript for view transitions
+::view-transition-group(*) {
+	animation-duration: 0.3s;
+}
+` + keyframes
+}
+
+// RenderJS generates the JavaScript for view transitions, or "" with
+// TransitionNone. A "gosite:mainswap" event is dispatched on document once a
+// fetched <main> is in place and the URL updated.
 func (n *NavbarBuilder) RenderJS() string {
+	if n.site.Cfg.PageTransition == TransitionNone {
+		return ""
+	}
 	return `// View Transition API for smooth page navigation
 (function() {
 	// Check if View Transition API is supported
@@ -212,63 +270,72 @@ func (n *NavbarBuilder) RenderJS() string {
 		// Check if it's a same-origin navigation
 		if (url.origin !== location.origin) return;
 
+		// Same-page anchors are scrolled to, not fetched
+		if (url.pathname === location.pathname && url.hash) return;
+
 		// Prevent default navigation
 		e.preventDefault();
 
 		// Start view transition
 		document.startViewTransition(async () => {
-			// Fetch the new page
-			const response = await fetch(url.href);
-			const html = await response.text();
-
-			// Parse the HTML
-			const parser = new DOMParser();
-			const doc = parser.parseFromString(html, 'text/html');
-
-			// Update the document title
-			document.title = doc.title;
-
-			// Replace the main content
-			const newMain = doc.querySelector('main');
-			const currentMain = document.querySelector('main');
-
-			if (newMain && currentMain) {
-				currentMain.replaceWith(newMain);
-			} else {
-				// Fallback: replace entire body content
-				document.body.innerHTML = doc.body.innerHTML;
+			if (!(await swapMain(url.href))) {
+				// Not a page we can swap in: navigate normally
+				location.href = url.href;
+				return;
 			}
 
 			// Update the URL
 			history.pushState({}, '', url.href);
-
-			// Re-attach event listeners after DOM update
-			initializeEventListeners();
+			document.dispatchEvent(new Event('gosite:mainswap'));
 		});
 	});
 
 	// Handle browser back/forward buttons
 	window.addEventListener('popstate', function() {
 		document.startViewTransition(async () => {
-			const response = await fetch(location.href);
-			const html = await response.text();
-			const parser = new DOMParser();
-			const doc = parser.parseFromString(html, 'text/html');
+			if (!(await swapMain(location.href))) {
+				location.reload();
+				return;
+			}
+			document.dispatchEvent(new Event('gosite:mainswap'));
+		});
+	});
 
-			document.title = doc.title;
+	// Fetch href and replace the current <main> with the fetched one. Returns
+	// false, leaving the page untouched, for error or redirected responses,
+	// non-HTML content and documents without a <main>.
+	async function swapMain(href) {
+		let response;
+		try {
+			response = await fetch(href);
+		} catch (err) {
+			return false;
+		}
 
-			const newMain = doc.querySelector('main');
-			const currentMain = document.querySelector('main');
+		const type = response.headers.get('Content-Type') || '';
+		if (!response.ok || response.redirected || !type.includes('text/html')) {
+			return false;
+		}
 
-			if (newMain && currentMain) {
-				currentMain.replaceWith(newMain);
-			} else {
-				document.body.innerHTML = doc.body.innerHTML;
-			}
+		// Parse the HTML
+		const html = await response.text();
+		const parser = new DOMParser();
+		const doc = parser.parseFromString(html, 'text/html');
 
-			initializeEventListeners();
-		});
-	});
+		const newMain = doc.querySelector('main');
+		const currentMain = document.querySelector('main');
+		if (!newMain || !currentMain) {
+			return false;
+		}
+
+		// Update the document title and replace the main content
+		document.title = doc.title;
+		currentMain.replaceWith(newMain);
+
+		// Re-attach event listeners after DOM update
+		initializeEventListeners();
+		return true;
+	}
 
 	// Function to reinitialize event listeners after DOM updates
 	function initializeEventListeners() {
@@ -278,3 +345,87 @@ func (n *NavbarBuilder) RenderJS() string {
 })();
 `
 }
+
+// RenderSmoothScrollJS generates the JavaScript that smooth-scrolls same-page
+// anchor links, leaving room for the sticky navbar.
+func (n *NavbarBuilder) RenderSmoothScrollJS() string {
+	return `// Smooth scroll for in-page anchor links
+(function() {
+	document.addEventListener('click', function(e) {
+		const link = e.target.closest('a[href*="#"]');
+		if (!link || link.target === '_blank') return;
+
+		const url = new URL(link.href);
+		if (url.origin !== location.origin || url.pathname !== location.pathname || !url.hash) return;
+
+		const target = document.getElementById(decodeURIComponent(url.hash.slice(1)));
+		if (!target) return;
+
+		e.preventDefault();
+
+		// Keep the target below the sticky navbar
+		const nav = document.querySelector('.main-nav, .navbar');
+		const offset = nav ? nav.offsetHeight : 0;
+		const top = target.getBoundingClientRect().top + window.pageYOffset - offset;
+
+		const reduce = window.matchMedia('(prefers-reduced-motion: reduce)').matches;
+		window.scrollTo({ top: top, behavior: reduce ? 'auto' : 'smooth' });
+		history.pushState(null, '', url.hash);
+	});
+})();
+`
+}
+
+// RenderScrollSpyJS generates the JavaScript that marks the nav link of the
+// section in view as active. Only same-page anchor links are watched; the
+// sections are observed again after a view transition swaps <main>.
+func (n *NavbarBuilder) RenderScrollSpyJS() string {
+	return `// Scroll spy for in-page anchor links
+(function() {
+	if (!('IntersectionObserver' in window)) return;
+
+	let observer = null;
+
+	function observe() {
+		const links = new Map();
+		document.querySelectorAll('.main-nav a[href*="#"], a.nav-link[href*="#"]').forEach(function(link) {
+			const url = new URL(link.href);
+			if (url.origin !== location.origin || url.pathname !== location.pathname || !url.hash) return;
+
+			const section = document.getElementById(decodeURIComponent(url.hash.slice(1)));
+			if (!section) return;
+			if (!links.has(section)) links.set(section, []);
+			links.get(section).push(link);
+		});
+		if (links.size === 0) return;
+
+		observer = new IntersectionObserver(function(entries) {
+			entries.forEach(function(entry) {
+				if (!entry.isIntersecting) return;
+				links.forEach(function(sectionLinks, section) {
+					sectionLinks.forEach(function(link) {
+						link.classList.toggle('active', section === entry.target);
+					});
+				});
+			});
+		}, { rootMargin: '-40% 0px -55% 0px' });
+
+		links.forEach(function(_, section) {
+			observer.observe(section);
+		});
+	}
+
+	observe();
+
+	// The old sections left the DOM with the previous <main>
+	document.addEventListener('gosite:mainswap', function() {
+		if (observer) observer.disconnect();
+		observer = null;
+		document.querySelectorAll('.main-nav a.active, a.nav-link.active').forEach(function(link) {
+			link.classList.remove('active');
+		});
+		observe();
+	});
+})();
+`
+}
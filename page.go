@@ -1,17 +1,53 @@
 package gosite
 
 import (
+	"github.com/cdvelop/gosite/components/i18n"
 	. "github.com/cdvelop/tinystring"
 )
 
 // Page represents a single HTML page. Its fields are unexported to maintain
 // a controlled, fluent API.
 type Page struct {
-	site     SiteLink
-	sections []*Section
-	title    string
-	filename string
-	head     []string
+	site        SiteLink
+	sections    []*Section
+	title       string
+	filename    string
+	head        []string
+	description string
+	keywords    []string
+	canonical   string
+	alternates  []alternateLink
+	stylesheets []string
+	scripts     []pageScript
+	toc         bool
+}
+
+// pageScript is an external script loaded by a single page.
+type pageScript struct {
+	src      string
+	deferred bool
+}
+
+// alternateLink is a translated version of a page.
+type alternateLink struct {
+	lang string
+	url  string
+}
+
+// clone returns a copy of the page and its sections attached to site.
+func (p *Page) clone(site SiteLink) *Page {
+	c := *p
+	c.site = site
+	c.head = append([]string(nil), p.head...)
+	c.keywords = append([]string(nil), p.keywords...)
+	c.alternates = append([]alternateLink(nil), p.alternates...)
+	c.stylesheets = append([]string(nil), p.stylesheets...)
+	c.scripts = append([]pageScript(nil), p.scripts...)
+	c.sections = make([]*Section, len(p.sections))
+	for i, section := range p.sections {
+		c.sections[i] = section.clone(&c, site)
+	}
+	return &c
 }
 
 // NewSection adds a new section to the page and returns it for chaining.
@@ -26,53 +62,299 @@ func (p *Page) NewSection(title string) *Section {
 	return section
 }
 
+// SetFilename overrides the output file name of the page. Nav links use the
+// final name since they are built at render time.
+func (p *Page) SetFilename(filename string) *Page {
+	p.filename = filename
+	return p
+}
+
+// SetDescription sets the page <meta name="description">. Nothing is emitted
+// while it is empty.
+func (p *Page) SetDescription(description string) *Page {
+	p.description = description
+	return p
+}
+
+// SetKeywords sets the page <meta name="keywords">, joined with ", ". Empty
+// keywords are skipped.
+func (p *Page) SetKeywords(keywords []string) *Page {
+	p.keywords = append([]string(nil), keywords...)
+	return p
+}
+
+// EnableTOC inserts the table of contents (see RenderTOC) at the top of the
+// page content.
+func (p *Page) EnableTOC() *Page {
+	p.toc = true
+	return p
+}
+
+// RenderTOC returns a table of contents linking each titled section to its
+// anchor id, or "" when no section has a title.
+func (p *Page) RenderTOC() string {
+	items := ""
+	for _, section := range p.sections {
+		if section.Title == "" {
+			continue
+		}
+		items += Fmt("    <li><a href=\"#%s\">%s</a></li>\n",
+			Convert(section.anchorID()).EscapeAttr(), Convert(section.Title).EscapeHTML())
+	}
+	if items == "" {
+		return ""
+	}
+	return Fmt("<nav class=\"toc\" aria-label=\"%s\">\n  <ul>\n%s  </ul>\n</nav>\n",
+		Translate(i18n.D.Contents).EscapeAttr(), items)
+}
+
+// SetCanonical sets the canonical URL of the page. Relative URLs are
+// resolved against Config.BaseURL.
+func (p *Page) SetCanonical(url string) *Page {
+	p.canonical = url
+	return p
+}
+
+// AddAlternate declares a translated version of the page for hreflang, e.g.
+// AddAlternate("en", "/en/index.html"). Relative URLs are resolved against
+// Config.BaseURL.
+func (p *Page) AddAlternate(lang, url string) *Page {
+	p.alternates = append(p.alternates, alternateLink{lang: lang, url: url})
+	return p
+}
+
+// resourceHints lists the rel values accepted by AddResourceHint.
+var resourceHints = map[string]bool{
+	"preconnect": true, "dns-prefetch": true, "preload": true, "prefetch": true, "modulepreload": true,
+}
+
+// AddResourceHint adds a <link rel="..."> resource hint to the page head,
+// e.g. AddResourceHint("preload", "img/hero.webp", "image"). rel must be one of
+// preconnect, dns-prefetch, preload, prefetch or modulepreload; as is required
+// for preload. Font preloads get the crossorigin attribute they need.
+func (p *Page) AddResourceHint(rel, href, as string) error {
+	if !resourceHints[rel] {
+		return Err(D.Value, D.Not, D.Supported, "rel", rel)
+	}
+	if href == "" {
+		return Err(D.Value, D.Empty, "href")
+	}
+	if rel == "preload" && as == "" {
+		return Err(D.Value, D.Required, "as", rel)
+	}
+
+	link := Fmt("<link rel=\"%s\" href=\"%s\"", rel, Convert(href).EscapeAttr())
+	if as != "" {
+		link += Fmt(" as=\"%s\"", Convert(as).EscapeAttr())
+	}
+	if as == "font" {
+		link += " crossorigin"
+	}
+	p.head = append(p.head, link+">")
+	return nil
+}
+
+// AddStylesheet links a CSS file on this page only, after the site style.css.
+func (p *Page) AddStylesheet(href string) *Page {
+	p.stylesheets = append(p.stylesheets, href)
+	return p
+}
+
+// AddScript loads a JS file on this page only, after the site scripts.
+func (p *Page) AddScript(src string, deferred bool) *Page {
+	p.scripts = append(p.scripts, pageScript{src: src, deferred: deferred})
+	return p
+}
+
 // AddHead adds content to the <head> section of the page.
 func (p *Page) AddHead(content string) *Page {
 	p.head = append(p.head, content)
 	return p
 }
 
+// scriptNonce returns the ` nonce="..."` attribute for script tags, or "".
+func scriptNonce(cfg *Config) string {
+	if cfg.CSPNonce == "" {
+		return ""
+	}
+	return Fmt(" nonce=\"%s\"", Convert(cfg.CSPNonce).EscapeAttr())
+}
+
+// renderImportMap returns the import map mapping each keyed module to its
+// content-hashed file, e.g. {"imports": {"carousel": "./js/carousel-1a2b3c4d.js"}},
+// or "" when no module has a key. Keys and file names are slugs, so they need
+// no JSON escaping.
+func renderImportMap(modules []JSModule, nonce string) string {
+	entries := make([]string, 0, len(modules))
+	seen := make(map[string]bool)
+	for _, m := range modules {
+		if m.Key == "" || seen[m.Key] {
+			continue
+		}
+		seen[m.Key] = true
+		entries = append(entries, Fmt("\"%s\": \"./%s\"", m.Key, m.Src))
+	}
+	if len(entries) == 0 {
+		return ""
+	}
+	return Fmt("  <script%s type=\"importmap\">{\"imports\": {%s}}</script>\n", nonce, Convert(entries).Join(", ").String())
+}
+
+// renderMeta returns the description and keywords meta tags that are set.
+func (p *Page) renderMeta() string {
+	meta := ""
+	if desc := Convert(p.description).TrimSpace().String(); desc != "" {
+		meta += Fmt("  <meta name=\"description\" content=\"%s\">\n", Convert(desc).EscapeAttr())
+	}
+	var keywords []string
+	for _, k := range p.keywords {
+		if k = Convert(k).TrimSpace().String(); k != "" {
+			keywords = append(keywords, k)
+		}
+	}
+	if len(keywords) > 0 {
+		meta += Fmt("  <meta name=\"keywords\" content=\"%s\">\n", Convert(keywords).Join(", ").EscapeAttr())
+	}
+	return meta
+}
+
+// renderStylesheet returns the style.css link. With Config.InlineCriticalCSS
+// the critical CSS is inlined first and style.css loads without blocking
+// render; the onload swap needs a CSP allowing inline event handlers.
+func (p *Page) renderStylesheet() string {
+	critical := p.site.CriticalCSS()
+	if critical == "" {
+		return "  <link rel=\"stylesheet\" href=\"style.css\">\n"
+	}
+	// A literal </style inside the CSS would end the element early.
+	critical = Convert(critical).Replace("</style", "<\\/style").String()
+	return Fmt("  <style%s>\n%s</style>\n", scriptNonce(p.site.Config()), critical) +
+		"  <link rel=\"stylesheet\" href=\"style.css\" media=\"print\" onload=\"this.media='all'\">\n" +
+		"  <noscript><link rel=\"stylesheet\" href=\"style.css\"></noscript>\n"
+}
+
+// renderSections returns the markup of all sections, the content of <main>.
+// With the default IconsInline library, known icon-font <i> tags become SVGs.
+func (p *Page) renderSections() string {
+	b := Convert()
+	if p.toc {
+		b.Write(p.RenderTOC())
+	}
+	for _, section := range p.sections {
+		b.Write(section.Render())
+	}
+	html := b.String()
+	if lib := p.site.Config().IconLibrary; lib == "" || lib == IconsInline {
+		html = inlineIconTags(html)
+	}
+	return html
+}
+
 // RenderHTML generates the complete HTML for the page.
 func (p *Page) RenderHTML() string {
 	b := Convert()
 
 	// Build head entries
+	b.Write(p.renderMeta())
+	b.Write(renderGoogleFontsLinks(p.site.Config().GoogleFonts))
+	if p.site.Config().IconLibrary == IconsFontAwesome {
+		b.Write(Fmt("  <link rel=\"stylesheet\" href=\"%s\" crossorigin=\"anonymous\" referrerpolicy=\"no-referrer\">\n", fontAwesomeCSS))
+	}
+	b.Write(renderHighlightHead(p.site.Config()))
+	b.Write(renderAnalyticsHead(p.site.Config()))
+	for _, href := range p.stylesheets {
+		b.Write(Fmt("  <link rel=\"stylesheet\" href=\"%s\">\n", Convert(href).EscapeAttr()))
+	}
 	for _, h := range p.head {
 		b.Write("  ")
 		b.Write(h)
 		b.Write("\n")
 	}
+	baseURL := p.site.Config().BaseURL
+	if p.canonical != "" {
+		b.Write(Fmt("  <link rel=\"canonical\" href=\"%s\">\n", Convert(absoluteURL(baseURL, p.canonical)).EscapeAttr()))
+	}
+	for _, alt := range p.alternates {
+		b.Write(Fmt("  <link rel=\"alternate\" hreflang=\"%s\" href=\"%s\">\n",
+			Convert(alt.lang).EscapeAttr(), Convert(absoluteURL(baseURL, alt.url)).EscapeAttr()))
+	}
 	headHTML := b.String()
 
-	// Build sections
-	b.Reset()
-	for _, section := range p.sections {
-		b.Write(section.Render())
-	}
-	sectionsHTML := b.String()
+	sectionsHTML := p.renderSections()
 
 	title := Convert(p.title).EscapeHTML()
 
+	viewport := "width=device-width, initial-scale=1.0"
+	if cfg := p.site.Config(); cfg.Viewport != "" {
+		viewport = cfg.Viewport
+	}
+	themeColor := p.site.Config().ThemeColor
+	if themeColor == "" && p.site.Config().ColorScheme != nil {
+		themeColor = p.site.Config().ColorScheme.Primary
+	}
+
+	lang := Convert(outputLang(p.site.Config())).ToLower().EscapeAttr()
+
 	// Optionally include nav if multiple pages exist
 	navHTML := ""
 	if p.site.PageCount() > 1 {
 		navHTML = p.site.BuildNav()
 	}
 
+	// Build body scripts
+	cfg := p.site.Config()
+	nonce := scriptNonce(cfg)
+	// A fresh buffer: String() released b back to the pool, so Reset would
+	// share it with the Convert calls below.
+	b = Convert()
+	if cfg.InlineCriticalJS {
+		for _, js := range p.site.JSBlocks() {
+			// A literal </script inside the block would end the element early.
+			b.Write(Fmt("  <script%s>\n%s\n</script>\n", nonce, Convert(js).Replace("</script", "<\\/script").String()))
+		}
+	} else if cfg.ESModules {
+		modules := p.site.JSModules(p)
+		b.Write(renderImportMap(modules, nonce))
+		for _, m := range modules {
+			b.Write(Fmt("  <script%s type=\"module\" src=\"%s\"></script>\n", nonce, Convert(m.Src).EscapeAttr()))
+		}
+		b.Write(Fmt("  <script%s nomodule src=\"script.js\"></script>\n", nonce))
+	} else {
+		switch cfg.ScriptLoading {
+		case ScriptSync:
+			b.Write(Fmt("  <script%s src=\"script.js\"></script>\n", nonce))
+		case ScriptModule:
+			b.Write(Fmt("  <script%s type=\"module\" src=\"script.js\"></script>\n", nonce))
+		default:
+			b.Write(Fmt("  <script%s defer src=\"script.js\"></script>\n", nonce))
+		}
+	}
+	for _, script := range p.scripts {
+		deferAttr := ""
+		if script.deferred {
+			deferAttr = " defer"
+		}
+		b.Write(Fmt("  <script%s%s src=\"%s\"></script>\n", nonce, deferAttr, Convert(script.src).EscapeAttr()))
+	}
+	if endpoint := cfg.RUMEndpoint; endpoint != "" {
+		b.Write(renderVitalsScript(endpoint, nonce))
+	}
+	b.Write(renderAnalyticsBody(cfg))
+	scriptsHTML := b.String()
+
 	tpl := `<!DOCTYPE html>
-<html lang="es">
+<html lang="%s">
 <head>
   <meta charset="UTF-8">
-  <meta name="viewport" content="width=device-width, initial-scale=1.0">
+  <meta name="viewport" content="%s">
+  <meta name="theme-color" content="%s">
   <title>%s</title>
-  <link rel="stylesheet" href="style.css">
-%s</head>
+%s%s</head>
 <body>
 %s  <main class="content">
 %s  </main>
-  <script src="script.js"></script>
-</body>
+%s</body>
 </html>
 `
-	return Fmt(tpl, title, headHTML, navHTML, sectionsHTML)
+	return formatHTML(Fmt(tpl, lang, Convert(viewport).EscapeAttr(), Convert(themeColor).EscapeAttr(), title, p.renderStylesheet(), headHTML, navHTML, sectionsHTML, scriptsHTML), p.site.Config().PrettyHTML)
 }
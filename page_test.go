@@ -0,0 +1,947 @@
+package gosite_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/cdvelop/gosite"
+	"github.com/cdvelop/gosite/components/card"
+	"github.com/cdvelop/gosite/components/carousel"
+	"github.com/cdvelop/gosite/components/content/packagecard"
+	"github.com/cdvelop/gosite/components/content/postcard"
+	"github.com/cdvelop/gosite/components/forms/form"
+	"github.com/cdvelop/gosite/components/navigation/backtotop"
+	"github.com/cdvelop/tinystring"
+)
+
+// setLang selects the tinystring output language for the test and restores
+// the previous one when it ends.
+func setLang(t *testing.T, lang string) {
+	prev := tinystring.OutLang(nil)
+	tinystring.OutLang(lang)
+	t.Cleanup(func() { tinystring.OutLang(prev) })
+}
+
+// newMemorySite returns a site whose generated files are captured in memory,
+// keyed by their output path.
+func newMemorySite(cfg *gosite.Config) (*gosite.Site, map[string]string) {
+	files := make(map[string]string)
+	if cfg.OutputDir == "" {
+		cfg.OutputDir = "out"
+	}
+	cfg.WriteFile = func(path, content string) error {
+		files[path] = content
+		return nil
+	}
+	return gosite.New(cfg), files
+}
+
+func TestVitalsScriptOnlyWhenConfigured(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "RUM"})
+	site.NewPage("Home", "index.html")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if strings.Contains(files["out/index.html"], "data-rum-endpoint") {
+		t.Error("vitals script present without RUMEndpoint")
+	}
+
+	site, files = newMemorySite(&gosite.Config{Title: "RUM", RUMEndpoint: "/rum?site=a&b"})
+	site.NewPage("Home", "index.html")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	html := files["out/index.html"]
+	if !strings.Contains(html, `data-rum-endpoint="/rum?site=a&amp;b"`) {
+		t.Errorf("vitals script missing or endpoint not escaped:\n%s", html)
+	}
+	for _, metric := range []string{"largest-contentful-paint", "layout-shift", "sendBeacon"} {
+		if !strings.Contains(html, metric) {
+			t.Errorf("vitals script missing %q", metric)
+		}
+	}
+}
+
+func TestAnalytics(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Stats"})
+	site.NewPage("Home", "index.html")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if strings.Contains(files["out/index.html"], "gtag") {
+		t.Error("analytics injected without Config.Analytics")
+	}
+
+	site, files = newMemorySite(&gosite.Config{Title: "Stats", CSPNonce: "n1", Analytics: gosite.Analytics{
+		GA4MeasurementID: `G-ABC123"`,
+		HeadSnippet:      `<meta name="stats" content="on">`,
+		BodySnippet:      `<noscript><img src="/pixel.gif" alt=""></noscript>`,
+	}})
+	site.NewPage("Home", "index.html")
+	site.NewPage("About", "about.html")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	for _, name := range []string{"out/index.html", "out/about.html"} {
+		html := files[name]
+		head, body, _ := strings.Cut(html, "</head>")
+		for _, want := range []string{
+			`<script nonce="n1" async src="https://www.googletagmanager.com/gtag/js?id=G-ABC123&quot;"></script>`,
+			`<script nonce="n1" data-ga-id="G-ABC123&quot;">`,
+			"gtag('config', document.currentScript.dataset.gaId);",
+			`<meta name="stats" content="on">`,
+		} {
+			if !strings.Contains(head, want) {
+				t.Errorf("%s head missing %s:\n%s", name, want, html)
+			}
+		}
+		if !strings.HasSuffix(strings.TrimSpace(body), `<noscript><img src="/pixel.gif" alt=""></noscript></body></html>`) {
+			t.Errorf("%s body snippet not at the end of the body:\n%s", name, html)
+		}
+	}
+}
+
+func TestAnalyticsRequireConsent(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Stats", Analytics: gosite.Analytics{GA4MeasurementID: "G-1", RequireConsent: true}})
+	site.NewPage("Home", "index.html")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	html := files["out/index.html"]
+	for _, want := range []string{
+		`<script type="text/plain" data-consent="analytics" async src="https://www.googletagmanager.com/gtag/js?id=G-1"></script>`,
+		`<script type="text/plain" data-consent="analytics" data-ga-id="G-1">`,
+		"document.addEventListener('consent:accept', activate",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("page missing %s:\n%s", want, html)
+		}
+	}
+	if strings.Count(html, "<script async") != 0 || strings.Count(html, "data-ga-id") != 1 {
+		t.Errorf("analytics runs before consent:\n%s", html)
+	}
+
+	site, files = newMemorySite(&gosite.Config{Title: "Stats", Analytics: gosite.Analytics{
+		RequireConsent: true,
+		HeadSnippet:    `<script src="https://stats.example/t.js"></script><SCRIPT>track()</SCRIPT>`,
+		BodySnippet:    `<script>pixel()</script>`,
+	}})
+	site.NewPage("Home", "index.html")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	html = files["out/index.html"]
+	for _, want := range []string{
+		`<script type="text/plain" data-consent="analytics" src="https://stats.example/t.js"></script>`,
+		`<SCRIPT type="text/plain" data-consent="analytics">track()</SCRIPT>`,
+		`<script type="text/plain" data-consent="analytics">pixel()</script><script>`,
+		"document.addEventListener('consent:accept', activate",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("page missing %s:\n%s", want, html)
+		}
+	}
+	if strings.Contains(html, "<script src=") || strings.Contains(html, "<SCRIPT>") || strings.Contains(html, "<script>pixel") {
+		t.Errorf("snippet scripts run before consent:\n%s", html)
+	}
+}
+
+func TestPrettyHTML(t *testing.T) {
+	const style = "<style>\n  .a  {  color: red; }\n</style>"
+
+	site, files := newMemorySite(&gosite.Config{})
+	site.NewPage("Home", "index.html").AddHead(style).NewSection("Hello   World")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	html := files["out/index.html"]
+	if !strings.Contains(html, `</head><body><main class="content"><section`) {
+		t.Errorf("inter-tag whitespace not stripped:\n%s", html)
+	}
+	if !strings.Contains(html, style) {
+		t.Error("whitespace inside <style> was modified")
+	}
+	if !strings.Contains(html, "<h1>Hello World</h1>") {
+		t.Errorf("text whitespace not collapsed:\n%s", html)
+	}
+
+	site, files = newMemorySite(&gosite.Config{PrettyHTML: true})
+	site.NewPage("Home", "index.html").AddHead(style).NewSection("Hello")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	html = files["out/index.html"]
+	for _, line := range []string{"\n  <head>\n", "\n    <title>Home</title>\n", "\n        <h1>Hello</h1>\n"} {
+		if !strings.Contains(html, line) {
+			t.Errorf("pretty output missing %q:\n%s", line, html)
+		}
+	}
+	if !strings.Contains(html, style) {
+		t.Error("whitespace inside <style> was modified in pretty mode")
+	}
+}
+
+func TestMinifiedHTMLKeepsInlineSpaces(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{})
+	site.NewPage("Home", "index.html").NewSection("").
+		AddRaw("<p>\n  <a href=\"a\">A</a>\n  <a href=\"b\">B</a>   <b>bold</b>\t<em>em</em>\n</p>\n<div>x</div>")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	html := files["out/index.html"]
+	if !strings.Contains(html, `<p> <a href="a">A</a> <a href="b">B</a> <b>bold</b> <em>em</em> </p><div>x</div>`) {
+		t.Errorf("spaces between inline elements not kept as one space:\n%s", html)
+	}
+}
+
+func TestESModules(t *testing.T) {
+	build := func(extra bool) map[string]string {
+		site, files := newMemorySite(&gosite.Config{ESModules: true})
+		site.NewPage("Home", "index.html").NewSection("Gallery").Add(&carousel.Carousel{})
+		about := site.NewPage("About", "about.html").NewSection("Team")
+		if extra {
+			about.Add(&backtotop.BackToTop{})
+		}
+		if err := site.Generate(); err != nil {
+			t.Fatalf("Generate: %v", err)
+		}
+		return files
+	}
+	files := build(false)
+
+	html := files["out/index.html"]
+	src := regexp.MustCompile(`js/carousel-[0-9a-f]{8}\.js`).FindString(html)
+	if src == "" {
+		t.Fatalf("index.html does not load a hashed carousel module:\n%s", html)
+	}
+	for _, tag := range []string{
+		`<script type="importmap">{"imports": {"carousel": "./` + src + `"}}</script>`,
+		`<script type="module" src="` + src + `"></script>`,
+		`<script nomodule src="script.js"></script>`,
+	} {
+		if !strings.Contains(html, tag) {
+			t.Errorf("index.html missing %s", tag)
+		}
+	}
+	if !strings.Contains(files["out/"+src], "carousel") {
+		t.Error("carousel module not written")
+	}
+	if _, ok := files["out/script.js"]; !ok {
+		t.Error("fallback bundle not written")
+	}
+
+	about := files["out/about.html"]
+	if strings.Contains(about, "carousel") || strings.Contains(about, "importmap") {
+		t.Errorf("page without the carousel references its module:\n%s", about)
+	}
+	if !strings.Contains(about, `<script type="module" src="js/`) {
+		t.Errorf("site-wide modules (navbar) missing from about.html:\n%s", about)
+	}
+
+	if again := build(true); !strings.Contains(again["out/index.html"], src) {
+		t.Error("adding a component to another page renamed the carousel module")
+	}
+}
+
+func TestComponentStringsFollowLang(t *testing.T) {
+	contact := &form.Form{Config: form.Config{Action: "/send", Method: "POST"}}
+
+	setLang(t, "EN")
+	en := contact.RenderHTML()
+	setLang(t, "ES")
+	es := contact.RenderHTML()
+
+	if !strings.Contains(en, "Send Message") {
+		t.Errorf("english submit label missing:\n%s", en)
+	}
+	if !strings.Contains(es, "Enviar Mensaje") {
+		t.Errorf("spanish submit label missing:\n%s", es)
+	}
+}
+
+func TestDefaultLangIsSpanish(t *testing.T) {
+	setLang(t, "EN")
+
+	build := func(lang string) string {
+		site, files := newMemorySite(&gosite.Config{Title: "Lang", Lang: lang})
+		site.NewPage("Home", "index.html").NewSection("S").Add(&form.Form{})
+		site.NewPage("About", "about.html").NewSection("A")
+		if err := site.Generate(); err != nil {
+			t.Fatalf("Generate: %v", err)
+		}
+		return files["out/index.html"]
+	}
+
+	es := build("")
+	if got := tinystring.OutLang(nil); got != "EN" {
+		t.Errorf("Generate left the output language at %s, want EN restored", got)
+	}
+	for _, want := range []string{`<html lang="es">`, "Enviar Mensaje", `aria-label="Abrir menú"`} {
+		if !strings.Contains(es, want) {
+			t.Errorf("default build missing %s:\n%s", want, es)
+		}
+	}
+	en := build("EN")
+	for _, want := range []string{`<html lang="en">`, "Send Message", `aria-label="Open menu"`, `aria-label="Close menu"`} {
+		if !strings.Contains(en, want) {
+			t.Errorf("english build missing %s:\n%s", want, en)
+		}
+	}
+}
+
+func TestFonts(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Fonts"})
+	site.NewPage("Home", "index.html").NewSection("S").Add(&form.Form{})
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if css := files["out/style.css"]; strings.Contains(css, "@font-face") ||
+		!strings.Contains(css, "body { font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif;") {
+		t.Errorf("default font stack changed:\n%s", css)
+	}
+	if strings.Contains(files["out/index.html"], "fonts.googleapis.com") {
+		t.Error("Google Fonts link present without GoogleFonts")
+	}
+
+	site, files = newMemorySite(&gosite.Config{
+		Title: "Fonts",
+		Fonts: []gosite.FontFace{
+			{Family: "Inter", Src: "fonts/inter.woff2", Weight: "100 900"},
+			{Family: "Inter", Src: "fonts/inter-italic.woff2", Style: "italic"},
+		},
+		GoogleFonts: []string{"Open Sans:wght@400;700", "Lora"},
+	})
+	site.NewPage("Home", "index.html").NewSection("S").Add(&form.Form{})
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	css := files["out/style.css"]
+	for _, want := range []string{
+		"@font-face { font-family: 'Inter'; src: url('fonts/inter.woff2') format('woff2'); font-weight: 100 900; font-display: swap; }",
+		"src: url('fonts/inter-italic.woff2') format('woff2'); font-style: italic;",
+		"body { font-family: 'Inter', -apple-system,",
+	} {
+		if !strings.Contains(css, want) {
+			t.Errorf("style.css missing %q", want)
+		}
+	}
+
+	want := `<link rel="stylesheet" href="https://fonts.googleapis.com/css2?family=Open+Sans:wght@400;700&amp;family=Lora&amp;display=swap">`
+	if html := files["out/index.html"]; !strings.Contains(html, want) {
+		t.Errorf("page missing Google Fonts link:\n%s", html)
+	}
+}
+
+// printable is a component contributing print rules.
+type printable struct{}
+
+func (printable) RenderHTML() string     { return `<div class="printable"></div>` }
+func (printable) RenderPrintCSS() string { return ".printable { break-inside: avoid; }\n" }
+
+func TestPrintStyles(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Print"})
+	site.NewPage("Home", "index.html").NewSection("S").Add(&form.Form{}).Add(printable{})
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if strings.Contains(files["out/style.css"], "@media print") {
+		t.Error("print styles emitted without PrintStyles")
+	}
+
+	site, files = newMemorySite(&gosite.Config{Title: "Print", PrintStyles: true})
+	site.NewPage("Home", "index.html").NewSection("S").Add(&form.Form{}).Add(printable{})
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	css := files["out/style.css"]
+	for _, want := range []string{
+		"@media print {\n.printable { break-inside: avoid; }\n}",
+		"footer, .footer",
+		"body { color: #000 !important; background: #fff !important; }",
+	} {
+		if !strings.Contains(css, want) {
+			t.Errorf("style.css missing %q", want)
+		}
+	}
+}
+
+func TestSectionIDAndClass(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Sections"})
+	page := site.NewPage("Home", "index.html")
+	page.NewSection("Our Services")
+	page.NewSection("About Us").SetID(`team"1`).SetClass("wide <dark>")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	html := files["out/index.html"]
+	for _, want := range []string{
+		`<section id="our-services" class="page">`,
+		`<section id="team&quot;1" class="page wide &lt;dark&gt;">`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("page missing %s\ngot:\n%s", want, html)
+		}
+	}
+}
+
+func TestUntitledSectionIDsAreDeterministic(t *testing.T) {
+	build := func() string {
+		site, files := newMemorySite(&gosite.Config{Title: "IDs"})
+		page := site.NewPage("Home", "index.html")
+		page.NewSection("Intro")
+		page.NewSection("")
+		page.NewSection("")
+		if err := site.Generate(); err != nil {
+			t.Fatalf("Generate: %v", err)
+		}
+		return files["out/index.html"]
+	}
+
+	first := build()
+	for _, want := range []string{`<section id="section-2" class="page">`, `<section id="section-3" class="page">`} {
+		if !strings.Contains(first, want) {
+			t.Errorf("page missing %s\ngot:\n%s", want, first)
+		}
+	}
+	if second := build(); second != first {
+		t.Error("output differs between identical builds")
+	}
+}
+
+func TestSectionIDsAreUniqueSlugs(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "IDs"})
+	page := site.NewPage("Home", "index.html")
+	page.NewSection("What's new? / 2024")
+	page.NewSection("")
+	page.NewSection("Section 2")
+	page.NewSection("FAQ")
+	page.NewSection("Help").SetID("faq")
+	page.NewSection("FAQ")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	html := files["out/index.html"]
+	for _, want := range []string{
+		`<section id="whats-new-2024" class="page">`,
+		`<section id="section-2" class="page">`,
+		`<section id="section-2-2" class="page">`,
+		`<section id="faq-2" class="page">`,
+		`<section id="faq" class="page">`,
+		`<section id="faq-3" class="page">`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("page missing %s\ngot:\n%s", want, html)
+		}
+	}
+}
+
+func TestPageFilenameSlug(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Slugs"})
+	site.NewPage("Home", "index.html")
+	site.NewPage("About Us", "")
+	site.NewPage("About Us", "")
+	site.NewPage("Contact", "").SetFilename("contact-us.html")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	for _, name := range []string{"out/index.html", "out/about_us.html", "out/about_us-2.html", "out/contact-us.html"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("missing %s", name)
+		}
+	}
+	nav := files["out/index.html"]
+	for _, href := range []string{`href="about_us.html"`, `href="about_us-2.html"`, `href="contact-us.html"`} {
+		if !strings.Contains(nav, href) {
+			t.Errorf("nav missing %s", href)
+		}
+	}
+}
+
+func TestCanonicalAndAlternates(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "SEO", BaseURL: "https://example.com/"})
+	site.NewPage("Home", "index.html").
+		AddHead(`<meta name="author" content="me">`).
+		SetCanonical("/index.html").
+		AddAlternate("en", "en/index.html").
+		AddAlternate("x-default", "https://cdn.example.org/index.html")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	html := files["out/index.html"]
+	want := `<meta name="author" content="me">` +
+		`<link rel="canonical" href="https://example.com/index.html">` +
+		`<link rel="alternate" hreflang="en" href="https://example.com/en/index.html">` +
+		`<link rel="alternate" hreflang="x-default" href="https://cdn.example.org/index.html">`
+	if !strings.Contains(html, want) {
+		t.Errorf("head missing canonical/alternate links after head entries\ngot:\n%s", html)
+	}
+}
+
+func TestScriptLoading(t *testing.T) {
+	for loading, want := range map[string]string{
+		"":                  `<script defer src="script.js"></script>`,
+		gosite.ScriptDefer:  `<script defer src="script.js"></script>`,
+		gosite.ScriptSync:   `<script src="script.js"></script>`,
+		gosite.ScriptModule: `<script type="module" src="script.js"></script>`,
+	} {
+		site, files := newMemorySite(&gosite.Config{Title: "JS", ScriptLoading: loading})
+		site.NewPage("Home", "index.html")
+		if err := site.Generate(); err != nil {
+			t.Fatalf("Generate: %v", err)
+		}
+		if html := files["out/index.html"]; !strings.Contains(html, want) {
+			t.Errorf("ScriptLoading %q: missing %s\ngot:\n%s", loading, want, html)
+		}
+	}
+}
+
+func TestResourceHints(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Hints"})
+	page := site.NewPage("Home", "index.html")
+
+	for _, hint := range [][3]string{
+		{"preconnect", "https://cdn.example.com", ""},
+		{"preload", "img/hero.webp", "image"},
+		{"preload", "fonts/inter.woff2", "font"},
+	} {
+		if err := page.AddResourceHint(hint[0], hint[1], hint[2]); err != nil {
+			t.Errorf("AddResourceHint%v: %v", hint, err)
+		}
+	}
+	for _, bad := range [][3]string{
+		{"stylesheet", "x.css", ""},
+		{"preload", "img/hero.webp", ""},
+		{"prefetch", "", ""},
+	} {
+		if err := page.AddResourceHint(bad[0], bad[1], bad[2]); err == nil {
+			t.Errorf("AddResourceHint%v: expected error", bad)
+		}
+	}
+
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	html := files["out/index.html"]
+	for _, want := range []string{
+		`<link rel="preconnect" href="https://cdn.example.com">`,
+		`<link rel="preload" href="img/hero.webp" as="image">`,
+		`<link rel="preload" href="fonts/inter.woff2" as="font" crossorigin>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("head missing %s", want)
+		}
+	}
+	if strings.Contains(html, "stylesheet\" href=\"x.css") {
+		t.Error("rejected hint was rendered")
+	}
+}
+
+func TestInlineCriticalJSWithNonce(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "CSP", InlineCriticalJS: true, CSPNonce: "r4nd0m", RUMEndpoint: "/rum"})
+	site.NewPage("Home", "index.html").NewSection("S").Add(&carousel.Carousel{})
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	if _, ok := files["out/script.js"]; ok {
+		t.Error("script.js written in inline mode")
+	}
+	blocks := site.JSBlocks()
+	if len(blocks) == 0 {
+		t.Fatal("JSBlocks returned no blocks")
+	}
+	html := files["out/index.html"]
+	if !strings.Contains(html, `<script nonce="r4nd0m">`+"\n"+blocks[0]+"\n</script>") {
+		t.Errorf("JS block not inlined with nonce:\n%s", html)
+	}
+	if !strings.Contains(html, `<script nonce="r4nd0m" data-rum-endpoint="/rum">`) {
+		t.Error("vitals script missing nonce")
+	}
+	if strings.Contains(html, `src="script.js"`) {
+		t.Error("external script tag present in inline mode")
+	}
+}
+
+func TestPerPageAssets(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Assets"})
+	site.NewPage("Home", "index.html")
+	site.NewPage("Map", "map.html").
+		AddStylesheet("https://unpkg.com/leaflet/dist/leaflet.css").
+		AddScript("https://unpkg.com/leaflet/dist/leaflet.js", false).
+		AddScript("js/map.js?v=1&x=2", true)
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	html := files["out/map.html"]
+	for _, want := range []string{
+		`<link rel="stylesheet" href="style.css"><link rel="stylesheet" href="https://unpkg.com/leaflet/dist/leaflet.css">`,
+		`<script defer src="script.js"></script><script src="https://unpkg.com/leaflet/dist/leaflet.js"></script><script defer src="js/map.js?v=1&amp;x=2"></script>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("map.html missing %s\ngot:\n%s", want, html)
+		}
+	}
+	if strings.Contains(files["out/index.html"], "leaflet") {
+		t.Error("per-page assets leaked into another page")
+	}
+}
+
+type uiModule struct{}
+
+func (uiModule) RenderUI() string { return "<p>render ui</p>" }
+
+type htmlModule struct{}
+
+func (htmlModule) RenderHTML() string { return "<p>direct</p>" }
+
+func TestSectionAddModule(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Modules"})
+	section := site.NewPage("Home", "index.html").NewSection("Main")
+
+	if err := section.AddModule(uiModule{}); err != nil {
+		t.Errorf("AddModule(RenderUI): %v", err)
+	}
+	if err := section.AddModule(htmlModule{}); err != nil {
+		t.Errorf("AddModule(HTMLRenderer): %v", err)
+	}
+	if err := section.AddModule(struct{}{}); err == nil {
+		t.Error("AddModule(struct{}{}): want error for a module that renders nothing")
+	}
+
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	html := files["out/index.html"]
+	if !strings.Contains(html, "<p>render ui</p>") || !strings.Contains(html, "<p>direct</p>") {
+		t.Errorf("modules not rendered:\n%s", html)
+	}
+}
+
+func TestSectionAddAll(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Bulk"})
+	var cards []any
+	for _, title := range []string{"One", "Two", "Three"} {
+		cards = append(cards, &card.Card{Title: title})
+	}
+	site.NewPage("Home", "index.html").NewSection("Cards").AddAll(cards...)
+
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	html := files["out/index.html"]
+	one, three := strings.Index(html, "<h3>One</h3>"), strings.Index(html, "<h3>Three</h3>")
+	if one < 0 || three < one || !strings.Contains(html, "<h3>Two</h3>") {
+		t.Errorf("cards missing or out of order:\n%s", html)
+	}
+	if strings.Count(files["out/style.css"], ".card {") != 1 {
+		t.Error("card CSS not collected exactly once")
+	}
+}
+
+func TestSectionAddCards(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Cards"})
+	features := []card.Card{{Title: "Fast"}, {Title: "Small", Description: "No deps"}, {Title: "Safe"}}
+	site.NewPage("Home", "index.html").NewSection("Features").AddCards(features...)
+	features[0].Title = "Changed later"
+
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	html := files["out/index.html"]
+	if !strings.Contains(html, `<div class="card-container"><div class="card"><h3>Fast</h3>`) ||
+		strings.Count(html, `<div class="card">`) != 3 || !strings.Contains(html, "No deps") {
+		t.Errorf("cards not rendered in the grid:\n%s", html)
+	}
+	if strings.Contains(html, "Changed later") {
+		t.Error("changing the slice after AddCards changed the section")
+	}
+	if strings.Count(files["out/style.css"], ".card {") != 1 {
+		t.Error("card CSS not collected exactly once")
+	}
+}
+
+func TestIconLibrary(t *testing.T) {
+	build := func(lib string) string {
+		site, files := newMemorySite(&gosite.Config{Title: "Icons", IconLibrary: lib})
+		site.NewPage("Home", "index.html").NewSection("Posts").
+			Add(&postcard.PostCard{Title: "Post", Date: "today", CommentsCount: "3"}).
+			Add(&packagecard.PackageCard{IconClass: "fas fa-unknown", Title: "Pkg"})
+		if err := site.Generate(); err != nil {
+			t.Fatalf("Generate: %v", err)
+		}
+		return files["out/index.html"]
+	}
+
+	html := build("")
+	if strings.Contains(html, `<i class="fas fa-clock">`) || !strings.Contains(html, `<svg class="icon-inline fas fa-clock"`) {
+		t.Errorf("inline mode did not replace the clock icon:\n%s", html)
+	}
+	if !strings.Contains(html, `<i class="fas fa-unknown"></i>`) {
+		t.Error("inline mode must keep icons it has no SVG for")
+	}
+	if strings.Contains(html, "font-awesome") {
+		t.Error("inline mode links Font Awesome")
+	}
+
+	html = build(gosite.IconsFontAwesome)
+	if !strings.Contains(html, "font-awesome/6.5.1/css/all.min.css") || !strings.Contains(html, `<i class="fas fa-clock"></i>`) {
+		t.Errorf("fontawesome mode: want the CDN link and the original <i> tags:\n%s", html)
+	}
+
+	html = build(gosite.IconsNone)
+	if strings.Contains(html, "font-awesome") || !strings.Contains(html, `<i class="fas fa-clock"></i>`) {
+		t.Error("none mode must not touch the icons")
+	}
+}
+
+func TestPageDescriptionAndKeywords(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Meta"})
+	site.NewPage("Home", "index.html").
+		SetDescription(`Clinic "Care" & <more>`).
+		SetKeywords([]string{"health", " ", "clinic "})
+	site.NewPage("About", "about.html")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	html := files["out/index.html"]
+	for _, want := range []string{
+		`<meta name="description" content="Clinic &quot;Care&quot; &amp; &lt;more&gt;">`,
+		`<meta name="keywords" content="health, clinic">`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("missing %s\n%s", want, html)
+		}
+	}
+	if about := files["out/about.html"]; strings.Contains(about, `name="description"`) || strings.Contains(about, `name="keywords"`) {
+		t.Errorf("page without description or keywords emits meta tags:\n%s", about)
+	}
+}
+
+func TestViewportAndThemeColor(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Theme"})
+	site.NewPage("Home", "index.html")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	want := `<meta name="viewport" content="width=device-width, initial-scale=1.0"><meta name="theme-color" content="` + gosite.DefaultColorScheme().Primary + `">`
+	if !strings.Contains(files["out/index.html"], want) {
+		t.Errorf("default head missing %s\n%s", want, files["out/index.html"])
+	}
+
+	site, files = newMemorySite(&gosite.Config{
+		Title:      "Theme",
+		ThemeColor: "#101010",
+		Viewport:   `width=device-width, initial-scale=1, maximum-scale=1"`,
+	})
+	site.NewPage("Home", "index.html")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	want = `<meta name="viewport" content="width=device-width, initial-scale=1, maximum-scale=1&quot;"><meta name="theme-color" content="#101010">`
+	if !strings.Contains(files["out/index.html"], want) {
+		t.Errorf("configured head missing %s\n%s", want, files["out/index.html"])
+	}
+}
+
+func TestPageTOC(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "TOC", Lang: "EN"})
+	page := site.NewPage("Guide", "index.html").EnableTOC()
+	page.NewSection("Getting Started")
+	page.NewSection("")
+	page.NewSection("Q & A").SetID("faq")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	html := files["out/index.html"]
+	want := `<main class="content"><nav class="toc" aria-label="Contents"><ul><li><a href="#getting-started">Getting Started</a></li><li><a href="#faq">Q &amp; A</a></li></ul></nav><section id="getting-started"`
+	if !strings.Contains(html, want) {
+		t.Errorf("missing %s\n%s", want, html)
+	}
+	if !strings.Contains(html, `<section id="faq"`) {
+		t.Error("TOC anchor does not match the section id")
+	}
+
+	if toc := site.NewPage("Empty", "empty.html").RenderTOC(); toc != "" {
+		t.Errorf("RenderTOC without titled sections = %q", toc)
+	}
+}
+
+func TestNavSmoothScroll(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Scroll"})
+	site.NewPage("Home", "index.html")
+	site.NewPage("About", "about.html")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	js := files["out/script.js"]
+	for _, want := range []string{"Smooth scroll for in-page anchor links", "prefers-reduced-motion: reduce", "nav.offsetHeight"} {
+		if !strings.Contains(js, want) {
+			t.Errorf("script.js missing %q", want)
+		}
+	}
+	if strings.Count(js, "Smooth scroll for in-page anchor links") != 1 {
+		t.Error("smooth scroll script bundled more than once")
+	}
+}
+
+func TestNavTransitionChecksResponse(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Transition"})
+	site.NewPage("Home", "index.html")
+	site.NewPage("About", "about.html")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	js := files["out/script.js"]
+	for _, want := range []string{"!response.ok", "text/html", "location.href = url.href", "if (!newMain || !currentMain)"} {
+		if !strings.Contains(js, want) {
+			t.Errorf("view transition script missing %q", want)
+		}
+	}
+	if strings.Contains(js, "document.body.innerHTML") {
+		t.Error("view transition still replaces the body of pages without <main>")
+	}
+}
+
+func TestPageTransition(t *testing.T) {
+	build := func(mode string) map[string]string {
+		site, files := newMemorySite(&gosite.Config{Title: "Transition", PageTransition: mode})
+		site.NewPage("Home", "index.html")
+		site.NewPage("About", "about.html")
+		if err := site.Generate(); err != nil {
+			t.Fatalf("Generate(%q): %v", mode, err)
+		}
+		return files
+	}
+
+	for _, mode := range []string{"", gosite.TransitionFade} {
+		files := build(mode)
+		if !strings.Contains(files["out/style.css"], "fade-out") || !strings.Contains(files["out/script.js"], "startViewTransition") {
+			t.Errorf("mode %q: want the fade transition CSS and JS", mode)
+		}
+	}
+
+	files := build(gosite.TransitionSlide)
+	if css := files["out/style.css"]; !strings.Contains(css, "both slide-out") || strings.Contains(css, "fade-out") {
+		t.Error("slide mode: want the slide keyframes instead of the fade")
+	}
+
+	files = build(gosite.TransitionNone)
+	if strings.Contains(files["out/style.css"], "view-transition") || strings.Contains(files["out/script.js"], "startViewTransition") {
+		t.Error("none mode still emits the View Transition CSS or JS")
+	}
+}
+
+func TestNavScrollSpy(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Spy"})
+	site.NewPage("Home", "index.html")
+	site.NewPage("About", "about.html")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	js := files["out/script.js"]
+	for _, want := range []string{"Scroll spy for in-page anchor links", "IntersectionObserver", "url.pathname !== location.pathname",
+		"addEventListener('gosite:mainswap'", "observer.disconnect()", "dispatchEvent(new Event('gosite:mainswap'))"} {
+		if !strings.Contains(js, want) {
+			t.Errorf("script.js missing %q", want)
+		}
+	}
+	if css := files["out/style.css"]; !strings.Contains(css, ".nav-link.active") || !strings.Contains(css, ".main-nav a.active") {
+		t.Error("style.css has no active nav link styles")
+	}
+}
+
+func TestInlineCriticalCSS(t *testing.T) {
+	site, files := newMemorySite(&gosite.Config{Title: "Critical", InlineCriticalCSS: true, CSPNonce: "n1"})
+	site.NewPage("Home", "index.html").NewSection("").Add(&card.Card{Title: "A"})
+	site.NewPage("About", "about.html")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	html := files["out/index.html"]
+	start := strings.Index(html, `<style nonce="n1">`)
+	end := strings.Index(html, "</style>")
+	if start < 0 || end < start {
+		t.Fatalf("no critical <style> in head:\n%s", html)
+	}
+	critical := html[start:end]
+	if !strings.Contains(critical, "--color-primary") || !strings.Contains(critical, ".main-nav") {
+		t.Error("critical CSS lacks the base variables or the nav styles")
+	}
+	if strings.Contains(critical, ".card {") {
+		t.Error("component CSS inlined as critical")
+	}
+	for _, want := range []string{
+		`<link rel="stylesheet" href="style.css" media="print" onload="this.media='all'">`,
+		`<noscript><link rel="stylesheet" href="style.css"></noscript>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("missing %s", want)
+		}
+	}
+	if !strings.Contains(files["out/style.css"], ".card {") {
+		t.Error("style.css lost the component CSS")
+	}
+
+	site, files = newMemorySite(&gosite.Config{Title: "Default"})
+	site.NewPage("Home", "index.html")
+	if err := site.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if html := files["out/index.html"]; strings.Contains(html, "<style") || !strings.Contains(html, `<link rel="stylesheet" href="style.css"></head>`) {
+		t.Errorf("default head should only link style.css:\n%s", html)
+	}
+}
+
+func TestSectionPlain(t *testing.T) {
+	site := gosite.New(&gosite.Config{Title: "Plain"})
+	page := site.NewPage("Home", "index.html")
+	grid := page.NewSection("Cards").Add(&card.Card{Title: "A"})
+	plain := page.NewSection("Form").Plain().Add(&card.Card{Title: "B"})
+
+	if html := grid.Render(); !strings.Contains(html, `<div class="card-container">`) {
+		t.Errorf("default section lost the card grid:\n%s", html)
+	}
+	html := plain.Render()
+	if strings.Contains(html, "card-container") {
+		t.Errorf("plain section still wraps its children:\n%s", html)
+	}
+	if !strings.HasPrefix(html, "<section id=\"form\" class=\"page\">\n  <h2>Form</h2>\n  <div class=\"card") {
+		t.Errorf("plain section does not render the child directly:\n%s", html)
+	}
+}
+
+func TestSectionHeadingLevel(t *testing.T) {
+	site := gosite.New(&gosite.Config{Title: "Headings"})
+	page := site.NewPage("Home", "index.html")
+	first := page.NewSection("Intro")
+	second := page.NewSection("More")
+	third := page.NewSection("Details").SetHeadingLevel(3)
+	ignored := page.NewSection("Odd").SetHeadingLevel(9)
+
+	for _, tc := range []struct {
+		section *gosite.Section
+		want    string
+	}{
+		{first, "<h1>Intro</h1>"},
+		{second, "<h2>More</h2>"},
+		{third, "<h3>Details</h3>"},
+		{ignored, "<h2>Odd</h2>"},
+	} {
+		if html := tc.section.Render(); !strings.Contains(html, tc.want) {
+			t.Errorf("want %s in:\n%s", tc.want, html)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package gosite
+
+// ColorSchemeFromPrimary derives a light scheme from a single hex color
+// ("#3f88bf" or "#38b"): the secondary is its complementary hue, the heading
+// a darker shade, the border a pale tint and the text a near-black of the
+// same hue. An invalid color returns DefaultColorScheme.
+func ColorSchemeFromPrimary(primary string) *ColorScheme {
+	h, sat, l, ok := hexToHSL(primary)
+	if !ok {
+		return DefaultColorScheme()
+	}
+	return &ColorScheme{
+		Primary:    hslToHex(h, sat, l),
+		Secondary:  hslToHex(h+180, sat, l),
+		Text:       hslToHex(h, sat*0.2, 0.12),
+		Background: "#ffffff",
+		Border:     hslToHex(h, sat*0.4, 0.9),
+		Heading:    hslToHex(h, sat, clamp01(l-0.15)),
+	}
+}
+
+// WithDark returns a dark variant of the scheme: a dark background and light
+// text tinted with the primary hue, and primary/secondary lightened to keep
+// their contrast. Colors that are not valid hex are kept as they are.
+func (cs *ColorScheme) WithDark() *ColorScheme {
+	dark := *cs
+	h, sat, _, ok := hexToHSL(cs.Primary)
+	if !ok {
+		return &dark
+	}
+	dark.Primary = lighten(cs.Primary, 0.15)
+	dark.Secondary = lighten(cs.Secondary, 0.15)
+	dark.Text = hslToHex(h, sat*0.15, 0.9)
+	dark.Background = hslToHex(h, sat*0.3, 0.1)
+	dark.Border = hslToHex(h, sat*0.3, 0.25)
+	dark.Heading = lighten(cs.Primary, 0.25)
+	return &dark
+}
+
+// lighten raises the lightness of a hex color by amount (0-1); invalid colors
+// are returned unchanged.
+func lighten(hex string, amount float64) string {
+	h, s, l, ok := hexToHSL(hex)
+	if !ok {
+		return hex
+	}
+	return hslToHex(h, s, clamp01(l+amount))
+}
+
+// hexToHSL parses "#rrggbb" or "#rgb" into hue (degrees), saturation and
+// lightness (0-1).
+func hexToHSL(hex string) (h, s, l float64, ok bool) {
+	if len(hex) == 4 && hex[0] == '#' {
+		hex = string([]byte{'#', hex[1], hex[1], hex[2], hex[2], hex[3], hex[3]})
+	}
+	if len(hex) != 7 || hex[0] != '#' {
+		return 0, 0, 0, false
+	}
+	var rgb [3]float64
+	for i := range rgb {
+		hi, ok1 := hexDigit(hex[1+2*i])
+		lo, ok2 := hexDigit(hex[2+2*i])
+		if !ok1 || !ok2 {
+			return 0, 0, 0, false
+		}
+		rgb[i] = float64(hi<<4|lo) / 255
+	}
+	r, g, b := rgb[0], rgb[1], rgb[2]
+
+	max, min := r, r
+	for _, c := range rgb {
+		if c > max {
+			max = c
+		}
+		if c < min {
+			min = c
+		}
+	}
+	l = (max + min) / 2
+	if max == min {
+		return 0, 0, l, true
+	}
+	d := max - min
+	if l > 0.5 {
+		s = d / (2 - max - min)
+	} else {
+		s = d / (max + min)
+	}
+	switch max {
+	case r:
+		h = (g - b) / d
+		if g < b {
+			h += 6
+		}
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	return h * 60, s, l, true
+}
+
+// hslToHex formats a hue (degrees, any range), saturation and lightness as "#rrggbb".
+func hslToHex(h, s, l float64) string {
+	for h < 0 {
+		h += 360
+	}
+	for h >= 360 {
+		h -= 360
+	}
+	s, l = clamp01(s), clamp01(l)
+
+	var q float64
+	if l < 0.5 {
+		q = l * (1 + s)
+	} else {
+		q = l + s - l*s
+	}
+	p := 2*l - q
+	hk := h / 360
+
+	out := []byte{'#', 0, 0, 0, 0, 0, 0}
+	for i, offset := range []float64{1.0 / 3, 0, -1.0 / 3} {
+		v := int(hueToRGB(p, q, hk+offset)*255 + 0.5)
+		out[1+2*i] = "0123456789abcdef"[v>>4]
+		out[2+2*i] = "0123456789abcdef"[v&15]
+	}
+	return string(out)
+}
+
+func hueToRGB(p, q, t float64) float64 {
+	if t < 0 {
+		t++
+	}
+	if t > 1 {
+		t--
+	}
+	switch {
+	case t < 1.0/6:
+		return p + (q-p)*6*t
+	case t < 0.5:
+		return q
+	case t < 2.0/3:
+		return p + (q-p)*(2.0/3-t)*6
+	}
+	return p
+}
+
+func hexDigit(c byte) (int, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0'), true
+	case c >= 'a' && c <= 'f':
+		return int(c-'a') + 10, true
+	case c >= 'A' && c <= 'F':
+		return int(c-'A') + 10, true
+	}
+	return 0, false
+}
+
+func clamp01(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
@@ -0,0 +1,74 @@
+//go:build !wasm
+
+package gosite
+
+import (
+	"compress/gzip"
+
+	. "github.com/cdvelop/tinystring"
+)
+
+// Encodings for Config.Precompress.
+const (
+	PrecompressGzip   = "gzip" // writes name.gz next to each file
+	PrecompressBrotli = "br"   // not available: the standard library has no brotli encoder
+)
+
+// precompressMinSize is the smallest file worth compressing; below it the
+// response headers outweigh the savings.
+const precompressMinSize = 1024
+
+// precompressExts lists the generated file types that are precompressed.
+var precompressExts = map[string]bool{".html": true, ".css": true, ".js": true, ".svg": true}
+
+// precompressWriter wraps write so that, for each encoding in
+// Config.Precompress, generated text files of at least precompressMinSize
+// bytes are also written compressed alongside the original.
+func (s *Site) precompressWriter(write func(name, content string) error) (func(name, content string) error, error) {
+	for _, enc := range s.Cfg.Precompress {
+		if enc != PrecompressGzip {
+			return nil, Err("precompress", enc, D.Not, D.Supported)
+		}
+	}
+	if len(s.Cfg.Precompress) == 0 {
+		return write, nil
+	}
+
+	return func(name, content string) error {
+		if err := write(name, content); err != nil {
+			return err
+		}
+		if len(content) < precompressMinSize || !precompressExts[Convert(name).PathExt().String()] {
+			return nil
+		}
+		compressed, err := gzipString(content)
+		if err != nil {
+			return err
+		}
+		return write(name+".gz", compressed)
+	}, nil
+}
+
+// gzipString returns content compressed with gzip at the best compression level.
+func gzipString(content string) (string, error) {
+	var buf byteSink
+	zw, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := zw.Write([]byte(content)); err != nil {
+		return "", err
+	}
+	if err := zw.Close(); err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+// byteSink is an io.Writer collecting everything written to it.
+type byteSink []byte
+
+func (b *byteSink) Write(p []byte) (int, error) {
+	*b = append(*b, p...)
+	return len(p), nil
+}
@@ -0,0 +1,74 @@
+package gosite
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// ComponentFactory builds a component from decoded properties, e.g. the
+// props of a CMS or JSON page definition.
+type ComponentFactory func(props map[string]any) HTMLRenderer
+
+// RegisterComponent makes a component type available by name to
+// Section.AddByName. Registering a name again replaces its factory.
+func (s *Site) RegisterComponent(name string, factory func(map[string]any) HTMLRenderer) {
+	if s.registry == nil {
+		s.registry = make(map[string]ComponentFactory)
+	}
+	s.registry[name] = factory
+}
+
+// NewComponent creates a registered component by name.
+func (s *Site) NewComponent(name string, props map[string]any) (HTMLRenderer, error) {
+	factory, ok := s.registry[name]
+	if !ok {
+		return nil, Err("component", name, D.Not, D.Found)
+	}
+	if props == nil {
+		props = map[string]any{}
+	}
+	return factory(props), nil
+}
+
+// AddByName creates a registered component from props and adds it to the
+// section. It returns an error when name was not registered.
+func (s *Section) AddByName(name string, props map[string]any) error {
+	component, err := s.site.NewComponent(name, props)
+	if err != nil {
+		return err
+	}
+	s.Add(component)
+	return nil
+}
+
+// PropString returns props[key] as a string, or "" when missing.
+func PropString(props map[string]any, key string) string {
+	switch v := props[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return Convert(v).String()
+	}
+}
+
+// PropInt returns props[key] as an int, accepting JSON numbers (float64) and
+// numeric strings. Missing or invalid values return 0.
+func PropInt(props map[string]any, key string) int {
+	switch v := props[key].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	case string:
+		n, _ := Convert(v).Int()
+		return n
+	}
+	return 0
+}
+
+// PropBool returns props[key] as a bool; missing or non-bool values are false.
+func PropBool(props map[string]any, key string) bool {
+	b, _ := props[key].(bool)
+	return b
+}
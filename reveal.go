@@ -0,0 +1,50 @@
+//go:build !wasm
+
+package gosite
+
+// scrollRevealCSS hides [data-reveal] elements only once the script has run
+// (it sets .reveal-ready on <html>), so content stays visible without JS.
+const scrollRevealCSS = `/* Scroll reveal */
+.reveal-ready [data-reveal] {
+  opacity: 0;
+  transform: translateY(24px);
+  transition: opacity 0.6s ease-out, transform 0.6s ease-out;
+}
+
+.reveal-ready [data-reveal].revealed {
+  opacity: 1;
+  transform: none;
+}
+
+@media (prefers-reduced-motion: reduce) {
+  .reveal-ready [data-reveal] {
+    opacity: 1;
+    transform: none;
+    transition: none;
+  }
+}
+`
+
+// scrollRevealJS adds the revealed class to [data-reveal] elements as they
+// scroll into view, once per element.
+const scrollRevealJS = `// Scroll reveal
+(function() {
+	const elements = document.querySelectorAll('[data-reveal]');
+	if (elements.length === 0 || !('IntersectionObserver' in window)) return;
+	if (window.matchMedia('(prefers-reduced-motion: reduce)').matches) return;
+
+	document.documentElement.classList.add('reveal-ready');
+
+	const observer = new IntersectionObserver(function(entries) {
+		entries.forEach(function(entry) {
+			if (!entry.isIntersecting) return;
+			entry.target.classList.add('revealed');
+			observer.unobserve(entry.target);
+		});
+	}, { threshold: 0.15 });
+
+	elements.forEach(function(el) {
+		observer.observe(el);
+	});
+})();
+`
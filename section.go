@@ -1,6 +1,7 @@
 package gosite
 
 import (
+	"github.com/cdvelop/gosite/components/card"
 	. "github.com/cdvelop/tinystring"
 )
 
@@ -11,53 +12,283 @@ type Section struct {
 	site     SiteLink
 	Title    string
 	ModuleID string
+	class    string
+	plain    bool // Render children directly, without the card-container grid
+	heading  int  // Title heading level; 0 picks h1 for the first section, h2 after
 	content  []any
+	events   []EventRenderer // Bound by Site.Mount in the frontend
+	scripts  []componentJS   // JS of the added components, for the page ES modules
+}
+
+// componentJS is the script of a component added to a section.
+type componentJS struct {
+	key string // JSKeyRenderer key or ""
+	js  string
+}
+
+// SetID sets the section anchor id, overriding the one derived from the title.
+func (s *Section) SetID(id string) *Section {
+	s.ModuleID = id
+	return s
+}
+
+// SetClass adds CSS classes to the section after the base "page" class.
+func (s *Section) SetClass(class string) *Section {
+	s.class = class
+	return s
+}
+
+// SetHeadingLevel sets the title element, <h1> to <h6>. Without it the first
+// section of the page uses <h1> and the rest <h2>, so a page has a single
+// <h1>. Levels outside 1-6 are ignored.
+func (s *Section) SetHeadingLevel(n int) *Section {
+	if n >= 1 && n <= 6 {
+		s.heading = n
+	}
+	return s
+}
+
+// headingLevel returns the title heading level.
+func (s *Section) headingLevel() int {
+	if s.heading != 0 {
+		return s.heading
+	}
+	if s.index() <= 1 {
+		return 1
+	}
+	return 2
+}
+
+// Plain renders the section children directly inside <section>, without the
+// card-container grid, for content such as forms or full-width heroes.
+func (s *Section) Plain() *Section {
+	s.plain = true
+	return s
 }
 
 // Add appends a new component to the section and returns the section for chaining.
 func (s *Section) Add(component any) *Section {
 	s.content = append(s.content, component)
+	s.registerAssets(component)
+	return s
+}
+
+// AddAll appends several components in order, as if Add were called for
+// each, and returns the section for chaining.
+func (s *Section) AddAll(components ...any) *Section {
+	for _, component := range components {
+		s.Add(component)
+	}
+	return s
+}
+
+// AddCards adds one card per value into the section's card grid, e.g. a list
+// of features built from data. Each card is copied, so reusing the slice
+// afterwards does not change the section. The card CSS is collected once.
+func (s *Section) AddCards(cards ...card.Card) *Section {
+	for i := range cards {
+		c := cards[i]
+		s.Add(&c)
+	}
+	return s
+}
+
+// uiModule is implemented by modules that return their markup from RenderUI
+// instead of implementing HTMLRenderer.
+type uiModule interface {
+	RenderUI() string
+}
+
+// AddModule adds a module to the section like Add, but reports modules that
+// render nothing instead of silently skipping them. Accepted modules implement
+// HTMLRenderer, CSSRenderer or JSRenderer, or RenderUI() string, whose markup
+// is added as raw HTML.
+func (s *Section) AddModule(module any) error {
+	switch m := module.(type) {
+	case HTMLRenderer, CSSRenderer, JSRenderer:
+		s.Add(m)
+	case uiModule:
+		s.AddRaw(m.RenderUI())
+	default:
+		return Err("module", D.Not, D.Supported)
+	}
+	return nil
+}
+
+// clone returns a copy of the section attached to page and site.
+func (s *Section) clone(page *Page, site SiteLink) *Section {
+	c := *s
+	c.page = page
+	c.site = site
+	c.content = append([]any(nil), s.content...)
+	c.events = append([]EventRenderer(nil), s.events...)
+	c.scripts = append([]componentJS(nil), s.scripts...)
+	return &c
+}
 
+// registerAssets hands the component's CSS/JS to the site, recursing into the
+// children of container components.
+func (s *Section) registerAssets(component any) {
 	// Cast and handle CSS if the component implements CSSRenderer.
 	if cssRenderer, ok := component.(CSSRenderer); ok {
-		s.site.AddCSS(cssRenderer.RenderCSS())
+		if keyed, ok := component.(CSSKeyRenderer); ok {
+			s.site.AddCSSWithKey(keyed.CSSKey(), cssRenderer.RenderCSS())
+		} else {
+			s.site.AddCSS(cssRenderer.RenderCSS())
+		}
+	}
+
+	// Print rules are opt-in at the site level.
+	if printRenderer, ok := component.(PrintCSSRenderer); ok && s.site.Config().PrintStyles {
+		if css := printRenderer.RenderPrintCSS(); css != "" {
+			s.site.AddCSS("@media print {\n" + css + "}\n")
+		}
+	}
+
+	// Record frontend behaviors; they are bound once the section is mounted.
+	if eventRenderer, ok := component.(EventRenderer); ok {
+		s.events = append(s.events, eventRenderer)
 	}
 
 	// Cast and handle JS if the component implements JSRenderer.
 	if jsRenderer, ok := component.(JSRenderer); ok {
-		s.site.AddJS(jsRenderer.RenderJS())
+		js := jsRenderer.RenderJS()
+		s.site.AddJS(js)
+		if js != "" {
+			key := ""
+			if keyed, ok := component.(JSKeyRenderer); ok {
+				key = anchorSlug(keyed.JSKey())
+			}
+			s.scripts = append(s.scripts, componentJS{key: key, js: js})
+		}
+	}
+
+	// Collect the assets of wrapped child components.
+	if container, ok := component.(ContainerRenderer); ok {
+		for _, child := range container.ChildComponents() {
+			s.registerAssets(child)
+		}
 	}
+}
 
+// AddRaw appends hand-written markup (e.g. a third-party embed) to the section
+// and returns the section for chaining. The markup is emitted verbatim by
+// Render: it is never escaped or sanitized, so the caller is responsible for
+// the safety of raw content.
+func (s *Section) AddRaw(html string) *Section {
+	s.content = append(s.content, rawHTML(html))
 	return s
 }
 
+// rawHTML is section content emitted verbatim.
+type rawHTML string
+
+// RenderHTML returns the raw markup unchanged.
+func (r rawHTML) RenderHTML() string {
+	return string(r)
+}
+
+// index returns the 1-based position of the section in its page.
+func (s *Section) index() int {
+	for i, sec := range s.page.sections {
+		if sec == s {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// anchorID returns the id of the rendered <section> element: ModuleID as set,
+// else the slug of the title (see anchorSlug), else "section-N" from the
+// position in the page so builds are reproducible. A derived id already used
+// by a ModuleID or an earlier section of the page gets -2, -3...
+func (s *Section) anchorID() string {
+	if s.ModuleID != "" {
+		return s.ModuleID
+	}
+	taken := make(map[string]bool)
+	for _, sec := range s.page.sections {
+		if sec.ModuleID != "" {
+			taken[sec.ModuleID] = true
+		}
+	}
+	for i, sec := range s.page.sections {
+		if sec.ModuleID != "" {
+			continue
+		}
+		slug := anchorSlug(sec.Title)
+		if slug == "" {
+			slug = Fmt("section-%d", i+1)
+		}
+		id := slug
+		for n := 2; taken[id]; n++ {
+			id = Fmt("%s-%d", slug, n)
+		}
+		if sec == s {
+			return id
+		}
+		taken[id] = true
+	}
+	return ""
+}
+
+// anchorSlug lowercases title and joins its words with "-", dropping the
+// characters other than letters, digits, "-" and "_", e.g.
+// "Our Services?" => "our-services".
+func anchorSlug(title string) string {
+	out := make([]rune, 0, len(title))
+	for _, r := range Convert(title).ToLower().Replace(" ", "-").String() {
+		switch {
+		case r == '-':
+			if len(out) > 0 && out[len(out)-1] != '-' {
+				out = append(out, r)
+			}
+		case r == '_', r >= 'a' && r <= 'z', r >= '0' && r <= '9', r >= 0x80:
+			out = append(out, r)
+		}
+	}
+	for len(out) > 0 && out[len(out)-1] == '-' {
+		out = out[:len(out)-1]
+	}
+	return string(out)
+}
+
 // Render generates the section's HTML.
 func (s *Section) Render() string {
 	b := Convert()
-	id := s.ModuleID
-	if id == "" {
-		// Generate a default ID from the title if none is provided.
-		id = Convert(s.Title).ToLower().Replace(" ", "-").String()
+	id := s.anchorID()
+	class := "page"
+	if s.class != "" {
+		class += " " + s.class
 	}
 	b.Write("<section id=\"")
 	b.Write(Convert(id).EscapeAttr())
-	b.Write("\" class=\"page\">\n")
+	b.Write("\" class=\"")
+	b.Write(Convert(class).EscapeAttr())
+	b.Write("\">\n")
 
 	if s.Title != "" {
-		b.Write("  <h1>")
+		level := s.headingLevel()
+		b.Write(Fmt("  <h%d>", level))
 		b.Write(Convert(s.Title).EscapeHTML())
-		b.Write("</h1>\n")
+		b.Write(Fmt("</h%d>\n", level))
+	}
+	indent := "  "
+	if !s.plain {
+		b.Write("  <div class=\"card-container\">\n")
+		indent = "    "
 	}
-	b.Write("  <div class=\"card-container\">\n")
 	for _, item := range s.content {
 		// Only render HTML if the component implements HTMLRenderer.
 		if htmlRenderer, ok := item.(HTMLRenderer); ok {
-			b.Write("    ")
+			b.Write(indent)
 			b.Write(htmlRenderer.RenderHTML())
 			b.Write("\n")
 		}
 	}
-	b.Write("  </div>\n")
+	if !s.plain {
+		b.Write("  </div>\n")
+	}
 	b.Write("</section>\n")
 	return b.String()
 }
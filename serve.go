@@ -0,0 +1,79 @@
+//go:build !wasm
+
+package gosite
+
+import (
+	"mime"
+	"net/http"
+	"sync"
+
+	. "github.com/cdvelop/tinystring"
+)
+
+// contentTypes maps generated file extensions to their HTTP content type,
+// overriding mime.TypeByExtension so the preview does not depend on the
+// system MIME tables.
+var contentTypes = map[string]string{
+	".html": "text/html; charset=utf-8",
+	".css":  "text/css; charset=utf-8",
+	".js":   "text/javascript; charset=utf-8",
+	".svg":  "image/svg+xml",
+	".gz":   "application/gzip",
+}
+
+// contentType returns the content type of the file name, or "" when the
+// extension is unknown and net/http should sniff it.
+func contentType(name string) string {
+	ext := Convert(name).PathExt().String()
+	if ct, ok := contentTypes[ext]; ok {
+		return ct
+	}
+	return mime.TypeByExtension(ext)
+}
+
+// Serve starts an HTTP preview server on addr for local development.
+// See Handler for how requests are mapped to generated files.
+func (s *Site) Serve(addr string, rebuild ...func(s *Site)) error {
+	return http.ListenAndServe(addr, s.Handler(rebuild...))
+}
+
+// Handler returns an http.Handler that serves the site from memory.
+//
+// Every request regenerates the files with GenerateToMap, so changes to
+// component state show up on reload. When rebuild callbacks are given, the
+// site's pages and assets are cleared and the callbacks are re-run before
+// each request, picking up changes to the page definitions themselves.
+// Paths ending in "/" are served from their index.html.
+func (s *Site) Handler(rebuild ...func(s *Site)) http.Handler {
+	var mu sync.Mutex
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		if len(rebuild) > 0 {
+			s.reset()
+			for _, build := range rebuild {
+				build(s)
+			}
+		}
+		files, err := s.GenerateToMap()
+		mu.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		name := Convert(r.URL.Path).TrimPrefix("/").String()
+		if name == "" || HasSuffix(name, "/") {
+			name += "index.html"
+		}
+		content, ok := files[name]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		if ct := contentType(name); ct != "" {
+			w.Header().Set("Content-Type", ct)
+		}
+		w.Write([]byte(content))
+	})
+}
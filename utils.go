@@ -1,6 +1,26 @@
 package gosite
 
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
 // assetBlock stores an asset's hash and content while preserving insertion order.
 type assetBlock struct {
+	Hash    string
 	Content string
 }
+
+// iconSymbol is a named SVG symbol of the icons.svg sprite.
+type iconSymbol struct {
+	Name string
+	Body string
+}
+
+// absoluteURL joins a relative url to base. Absolute (scheme or
+// protocol-relative) URLs and an empty base return url unchanged.
+func absoluteURL(base, url string) string {
+	if base == "" || Contains(url, "://") || HasPrefix(url, "//") {
+		return url
+	}
+	return Convert(base).TrimSuffix("/").String() + "/" + Convert(url).TrimPrefix("/").String()
+}
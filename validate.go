@@ -0,0 +1,134 @@
+//go:build !wasm
+
+package gosite
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// Validate generates the site in memory and checks every page for common
+// markup problems: duplicate element ids, unescaped "<" or ">" in text, links
+// with an empty or missing href, images without alt and images whose empty alt
+// is not marked decorative with role="presentation". It returns one
+// descriptive error per problem, in page order, or nil when the output is clean.
+func (s *Site) Validate() []error {
+	files, err := s.GenerateToMap()
+	if err != nil {
+		return []error{err}
+	}
+
+	var errs []error
+	for _, p := range s.pages {
+		errs = append(errs, validateHTML(p.filename, files[p.filename])...)
+	}
+	return errs
+}
+
+// validateHTML checks a single generated page; name prefixes each error.
+func validateHTML(name, html string) []error {
+	var errs []error
+	ids := make(map[string]bool)
+	for _, t := range tokenizeHTML(html) {
+		switch {
+		case !HasPrefix(t.text, "<"):
+			if Contains(t.text, ">") {
+				errs = append(errs, Err(name+":", "unescaped \">\" in text", snippet(t.text)))
+			}
+		case t.closing || HasPrefix(t.text, "<!"):
+			// Closing tags, comments and the doctype carry no attributes.
+		case !isTagStart(t.text):
+			errs = append(errs, Err(name+":", "unescaped \"<\" in text", snippet(t.text)))
+		default:
+			attrs := tagAttrs(t.text)
+			if id, ok := attrs["id"]; ok {
+				if ids[id] {
+					errs = append(errs, Err(name+":", "duplicate id", Fmt("%q", id)))
+				}
+				ids[id] = true
+			}
+			if href, ok := attrs["href"]; t.name == "a" && (!ok || href == "") {
+				errs = append(errs, Err(name+":", "<a> with empty href", snippet(t.text)))
+			}
+			if alt, ok := attrs["alt"]; t.name == "img" && !ok {
+				errs = append(errs, Err(name+":", "<img> without alt", snippet(t.text)))
+			} else if t.name == "img" && alt == "" && !isDecorative(attrs) {
+				// Either the alt text was forgotten or the image is decorative
+				errs = append(errs, Err(name+":", "<img> with empty alt not marked decorative", snippet(t.text)))
+			}
+		}
+	}
+	return errs
+}
+
+// isDecorative reports whether an element is explicitly hidden from assistive
+// technology.
+func isDecorative(attrs map[string]string) bool {
+	role := attrs["role"]
+	return role == "presentation" || role == "none" || attrs["aria-hidden"] == "true"
+}
+
+// isTagStart reports whether tag begins like an element, "<x" with x a letter.
+func isTagStart(tag string) bool {
+	if len(tag) < 2 {
+		return false
+	}
+	c := tag[1]
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// tagAttrs parses the attributes of an opening tag. Names are lower-cased;
+// attributes without a value map to "".
+func tagAttrs(tag string) map[string]string {
+	attrs := make(map[string]string)
+	i := 1
+	for i < len(tag) && !isAttrSpace(tag[i]) && tag[i] != '>' && tag[i] != '/' {
+		i++ // skip the element name
+	}
+	for i < len(tag) {
+		for i < len(tag) && (isAttrSpace(tag[i]) || tag[i] == '/') {
+			i++
+		}
+		start := i
+		for i < len(tag) && !isAttrSpace(tag[i]) && tag[i] != '=' && tag[i] != '>' && tag[i] != '/' {
+			i++
+		}
+		if start == i {
+			break
+		}
+		key := asciiLower(tag[start:i])
+		value := ""
+		if i < len(tag) && tag[i] == '=' {
+			i++
+			if i < len(tag) && (tag[i] == '"' || tag[i] == '\'') {
+				quote := tag[i]
+				end := Index(tag[i+1:], string(quote))
+				if end < 0 {
+					end = len(tag) - i - 1
+				}
+				value = tag[i+1 : i+1+end]
+				i += end + 2
+			} else {
+				start := i
+				for i < len(tag) && !isAttrSpace(tag[i]) && tag[i] != '>' {
+					i++
+				}
+				value = tag[start:i]
+			}
+		}
+		attrs[key] = value
+	}
+	return attrs
+}
+
+func isAttrSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\t' || c == '\r'
+}
+
+// snippet shortens markup for error messages.
+func snippet(s string) string {
+	s = collapseSpaces(s)
+	if len(s) > 60 {
+		s = s[:57] + "..."
+	}
+	return Fmt("%q", s)
+}
@@ -0,0 +1,67 @@
+package gosite
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// renderVitalsScript returns an inline script that measures Core Web Vitals
+// (LCP, CLS and INP) with PerformanceObserver and reports them to endpoint
+// via sendBeacon when the page is hidden. No external library is required.
+// nonceAttr is the optional CSP nonce attribute (see scriptNonce).
+func renderVitalsScript(endpoint, nonceAttr string) string {
+	//*js
+	return Fmt(`  <script%s data-rum-endpoint="%s">
+(function() {
+	var endpoint = document.currentScript.dataset.rumEndpoint;
+	if (!endpoint || !('PerformanceObserver' in window)) return;
+
+	var metrics = { lcp: 0, cls: 0, inp: 0 };
+	var sent = false;
+
+	function observe(type, callback, opts) {
+		try {
+			var po = new PerformanceObserver(function(list) {
+				list.getEntries().forEach(callback);
+			});
+			po.observe(Object.assign({ type: type, buffered: true }, opts || {}));
+		} catch (e) {
+			// Entry type not supported by this browser
+		}
+	}
+
+	observe('largest-contentful-paint', function(entry) {
+		metrics.lcp = entry.startTime;
+	});
+
+	observe('layout-shift', function(entry) {
+		if (!entry.hadRecentInput) metrics.cls += entry.value;
+	});
+
+	observe('event', function(entry) {
+		if (entry.interactionId && entry.duration > metrics.inp) metrics.inp = entry.duration;
+	}, { durationThreshold: 40 });
+
+	function report() {
+		if (sent) return;
+		sent = true;
+		var body = JSON.stringify({
+			page: location.pathname,
+			lcp: Math.round(metrics.lcp),
+			cls: Math.round(metrics.cls * 1000) / 1000,
+			inp: Math.round(metrics.inp)
+		});
+		if (navigator.sendBeacon) {
+			navigator.sendBeacon(endpoint, body);
+		} else {
+			fetch(endpoint, { method: 'POST', body: body, keepalive: true });
+		}
+	}
+
+	document.addEventListener('visibilitychange', function() {
+		if (document.visibilityState === 'hidden') report();
+	});
+	window.addEventListener('pagehide', report);
+})();
+  </script>
+`, nonceAttr, Convert(endpoint).EscapeAttr())
+}